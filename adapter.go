@@ -0,0 +1,145 @@
+package poteto
+
+import (
+	stdContext "context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type potetoContextKey struct{}
+
+// catch-all of Poteto.Mount
+// EX: "/legacy" -> "/legacy/*mountpath"
+const mountWildcard = "*mountpath"
+
+// request context exposing ctx store
+//
+//	r.Context().Value("user") -> ctx.Get("user")
+//
+// values set after wrapping are also visible
+type storeContext struct {
+	stdContext.Context
+	ctx Context
+}
+
+func (sc storeContext) Value(key any) any {
+	if _, ok := key.(potetoContextKey); ok {
+		return sc.ctx
+	}
+
+	if storeKey, ok := key.(string); ok {
+		if value, ok := sc.ctx.Get(storeKey); ok {
+			return value
+		}
+	}
+	return sc.Context.Value(key)
+}
+
+// get poteto Context from request passed to net/http handler
+// by poteto.WrapHandler, poteto.WrapMiddleware & Poteto.Mount
+func ContextFromRequest(r *http.Request) (Context, bool) {
+	ctx, ok := r.Context().Value(potetoContextKey{}).(Context)
+	return ctx, ok
+}
+
+// request whose context exposes ctx store
+func requestWithStore(ctx Context) *http.Request {
+	r := ctx.GetRequest()
+	if fromRequest, ok := ContextFromRequest(r); ok && fromRequest == ctx {
+		return r
+	}
+
+	return r.WithContext(storeContext{Context: r.Context(), ctx: ctx})
+}
+
+// Convert http.Handler into HandlerFunc
+//
+// ctx store value is available via request context
+//
+//	p.GET("/legacy", poteto.WrapHandler(legacyHandler))
+//
+//	func (h *LegacyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//	  user := r.Context().Value("user")
+//	}
+func WrapHandler(handler http.Handler) HandlerFunc {
+	return func(ctx Context) error {
+		handler.ServeHTTP(ctx.GetResponse(), requestWithStore(ctx))
+		return nil
+	}
+}
+
+// Convert net/http middleware into MiddlewareFunc
+//
+// ctx store value is available via request context
+// request & writer passed to next by the middleware are used by following handler
+//
+//	p.Register(poteto.WrapMiddleware(func(next http.Handler) http.Handler {
+//	  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	    next.ServeHTTP(w, r)
+//	  })
+//	}))
+func WrapMiddleware(middleware func(http.Handler) http.Handler) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx Context) error {
+			res := ctx.GetResponse()
+			origin := res.Writer
+
+			var nextErr error
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c, ok := ctx.(*context); ok {
+					c.request = r
+				}
+
+				// middleware may wrap writer ex: compression
+				res.Writer = w
+				defer func() {
+					res.Writer = origin
+				}()
+
+				nextErr = next(ctx)
+			}))
+
+			// origin is passed so that wrapped writer does not refer res itself
+			handler.ServeHTTP(origin, requestWithStore(ctx))
+			return nextErr
+		}
+	}
+}
+
+// handler of Poteto.Mount
+// prefix is stripped from request path
+func mountHandler(prefix string, handler http.Handler) HandlerFunc {
+	return func(ctx Context) error {
+		r := requestWithStore(ctx)
+
+		stripped := r.Clone(r.Context())
+		stripped.URL = new(url.URL)
+		*stripped.URL = *r.URL
+		stripped.URL.Path = stripMountPrefix(prefix, r.URL.Path)
+		if r.URL.RawPath != "" {
+			stripped.URL.RawPath = stripMountPrefix(prefix, r.URL.RawPath)
+		}
+
+		handler.ServeHTTP(ctx.GetResponse(), stripped)
+		return nil
+	}
+}
+
+// "/legacy", "/legacy/users" -> "/users"
+// "/legacy", "/legacy" -> "/"
+func stripMountPrefix(prefix, path string) string {
+	if prefix == "/" {
+		return path
+	}
+
+	// prefix may be matched w/ case folding
+	stripped := path
+	if len(path) >= len(prefix) && strings.EqualFold(path[:len(prefix)], prefix) {
+		stripped = path[len(prefix):]
+	}
+	if !strings.HasPrefix(stripped, "/") {
+		stripped = "/" + stripped
+	}
+	return stripped
+}
@@ -0,0 +1,187 @@
+package poteto
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	stdContext "context"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKeyForTest struct{}
+
+func TestWrapHandler(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(func(next HandlerFunc) HandlerFunc {
+		return func(ctx Context) error {
+			ctx.Set("user", "poteto")
+			return next(ctx)
+		}
+	})
+	p.GET("/legacy", WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := ContextFromRequest(r)
+		assert.True(t, ok)
+		assert.Equal(t, "/legacy", ctx.GetPath())
+
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, r.Context().Value("user").(string))
+	})))
+
+	// Act
+	res := p.Play(http.MethodGet, "/legacy")
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, res.Code)
+	assert.Equal(t, "poteto", res.Body.String())
+}
+
+func TestWrapMiddleware(t *testing.T) {
+	t.Run("pass request & writer to next", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Register(WrapMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Legacy", "true")
+				r = r.WithContext(stdContext.WithValue(r.Context(), ctxKeyForTest{}, "value"))
+				next.ServeHTTP(&upperWriterForTest{w}, r)
+			})
+		}))
+		p.GET("/users", func(ctx Context) error {
+			ctx.SetResponseHeader("X-Value", ctx.GetRequest().Context().Value(ctxKeyForTest{}).(string))
+			_, err := ctx.GetResponse().Write([]byte("hello"))
+			return err
+		})
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, "true", res.Header().Get("X-Legacy"))
+		assert.Equal(t, "value", res.Header().Get("X-Value"))
+		assert.Equal(t, "HELLO", res.Body.String())
+	})
+
+	t.Run("stop w/o calling next", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Register(WrapMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		}))
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.Equal(t, "", res.Body.String())
+	})
+
+	t.Run("return error of next", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Register(WrapMiddleware(func(next http.Handler) http.Handler {
+			return next
+		}))
+		p.GET("/users", func(ctx Context) error {
+			return NewHttpError(http.StatusBadRequest)
+		})
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestPoteto_Mount(t *testing.T) {
+	// Arrange
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "index:"+r.URL.Path)
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "users:"+r.URL.Path+":"+r.Context().Value("user").(string))
+	})
+
+	p := New()
+	p.Register(func(next HandlerFunc) HandlerFunc {
+		return func(ctx Context) error {
+			ctx.Set("user", "poteto")
+			return next(ctx)
+		}
+	})
+	err := p.Mount("/legacy/", mux)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{"prefix", http.MethodGet, "/legacy", "index:/"},
+		{"subpath", http.MethodGet, "/legacy/users", "users:/users:poteto"},
+		{"other method", http.MethodPost, "/legacy/users", "users:/users:poteto"},
+		{"not standard method", "PROPFIND", "/legacy/users", "users:/users:poteto"},
+		{"not standard method on prefix", "PURGE", "/legacy", "index:/"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(it.method, it.path)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, it.expected, res.Body.String())
+		})
+	}
+
+	t.Run("conflict w/ registered route", func(t *testing.T) {
+		// Act
+		err := p.Mount("/legacy", mux)
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+func TestStripMountPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		path     string
+		expected string
+	}{
+		{"subpath", "/legacy", "/legacy/users", "/users"},
+		{"prefix", "/legacy", "/legacy", "/"},
+		{"trailing slash", "/legacy", "/legacy/", "/"},
+		{"case folded", "/legacy", "/Legacy/users", "/users"},
+		{"root", "/", "/users", "/users"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := stripMountPrefix(it.prefix, it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+type upperWriterForTest struct {
+	http.ResponseWriter
+}
+
+func (w *upperWriterForTest) Write(b []byte) (int, error) {
+	return w.ResponseWriter.Write([]byte(strings.ToUpper(string(b))))
+}
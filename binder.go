@@ -1,6 +1,12 @@
 package poteto
 
 import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
 	"strings"
 
 	validator "github.com/go-playground/validator/v10"
@@ -19,6 +25,21 @@ type Binder interface {
 
 	// Bind with github.com/go-playground/validator/v10
 	BindWithValidate(ctx Context, object any) error
+
+	// Bind fields of struct by tag & validate
+	//
+	//   - param:"id" -> path param
+	//   - query:"page" -> query param
+	//   - header:"X-Tenant" -> request header
+	//   - cookie:"session" -> cookie
+	//   - json:"name" -> request body of application/json
+	//
+	// default:"10" is used if value is missing
+	// conversion is same as PathParamAs, slice field gets all values
+	//
+	// return HttpError of 400 if value or json body is invalid
+	// return perror.ErrInvalidBindTarget if object is not pointer to struct
+	BindAll(ctx Context, object any) error
 }
 
 type binder struct{}
@@ -33,12 +54,7 @@ func (b *binder) Bind(ctx Context, object any) error {
 		return perror.ErrZeroLengthContent
 	}
 
-	base, _, _ := strings.Cut(
-		ctx.GetRequestHeaderParam(constant.HeaderContentType), ";",
-	)
-	mediaType := strings.TrimSpace(base)
-
-	if mediaType != constant.APPLICATION_JSON {
+	if !isJsonContent(ctx) {
 		// if not application/json
 		return perror.ErrNotApplicationJson
 	}
@@ -54,6 +70,56 @@ func (b *binder) BindWithValidate(ctx Context, object any) error {
 		return err
 	}
 
+	return validateStruct(object)
+}
+
+func (b *binder) BindAll(ctx Context, object any) error {
+	rv := reflect.ValueOf(object)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return perror.ErrInvalidBindTarget
+	}
+
+	// body is optional ex: GET, form
+	// decoded only if struct has json field
+	if ctx.GetRequest().ContentLength != 0 && isJsonContent(ctx) && hasJsonField(rv.Elem().Type()) {
+		if err := ctx.JsonDeserialize(object); err != nil {
+			httpErr := NewHttpError(http.StatusBadRequest, "invalid json body")
+			httpErr.SetInternalError(err)
+			return httpErr
+		}
+	}
+
+	// path, query, header & cookie overwrite body
+	if err := bindFields(ctx, rv.Elem()); err != nil {
+		return err
+	}
+
+	return validateStruct(object)
+}
+
+func isJsonContent(ctx Context) bool {
+	base, _, _ := strings.Cut(
+		ctx.GetRequestHeaderParam(constant.HeaderContentType), ";",
+	)
+	return strings.TrimSpace(base) == constant.APPLICATION_JSON
+}
+
+// field w/ json tag including embedded struct
+func hasJsonField(rt reflect.Type) bool {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasJsonField(field.Type) {
+			return true
+		}
+
+		if _, ok := field.Tag.Lookup("json"); ok && field.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func validateStruct(object any) error {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	if err := validate.Struct(object); err != nil {
 		return err
@@ -61,3 +127,105 @@ func (b *binder) BindWithValidate(ctx Context, object any) error {
 
 	return nil
 }
+
+func bindFields(ctx Context, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field, value := rt.Field(i), rv.Field(i)
+
+		// embedded struct ex: Pagination
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			if err := bindFields(ctx, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		paramType, key, values := bindValues(ctx, field)
+		if len(values) != 0 {
+			if err := bindField(value, values); err != nil {
+				if errors.Is(err, perror.ErrUnsupportedParamType) {
+					return fmt.Errorf("%w: %s", err, field.Name)
+				}
+				return paramError(paramType, key, err)
+			}
+			continue
+		}
+
+		// json field keeps value of body
+		def, ok := field.Tag.Lookup(constant.BindTagDefault)
+		if !ok || (paramType == "" && !value.IsZero()) {
+			continue
+		}
+
+		if err := bindField(value, []string{def}); err != nil {
+			return fmt.Errorf("invalid default of %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// non empty values of the field by tag
+// paramType is empty if field has no tag
+func bindValues(ctx Context, field reflect.StructField) (string, string, []string) {
+	if key, ok := field.Tag.Lookup(constant.BindTagParam); ok {
+		value, _ := ctx.PathParam(key)
+		return constant.ParamTypePath, key, nonEmpty(value)
+	}
+
+	if key, ok := field.Tag.Lookup(constant.BindTagQuery); ok {
+		return constant.ParamTypeQuery, key, nonEmpty(ctx.QueryParams(key)...)
+	}
+
+	if key, ok := field.Tag.Lookup(constant.BindTagHeader); ok {
+		return "header", key, nonEmpty(ctx.ExtractRequestHeaderParam(http.CanonicalHeaderKey(key))...)
+	}
+
+	if key, ok := field.Tag.Lookup(constant.BindTagCookie); ok {
+		cookie, err := ctx.GetRequest().Cookie(key)
+		if err != nil {
+			return "cookie", key, nil
+		}
+		return "cookie", key, nonEmpty(cookie.Value)
+	}
+
+	return "", "", nil
+}
+
+// empty value is treated as missing same as Context.QueryParam
+func nonEmpty(values ...string) []string {
+	return slices.DeleteFunc(slices.Clone(values), func(value string) bool {
+		return value == ""
+	})
+}
+
+func bindField(field reflect.Value, values []string) error {
+	// ex: *int
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := bindField(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	// slice unmarshaled from text is single value ex: net.IP
+	if _, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok || field.Kind() != reflect.Slice {
+		return parseParam(field.Addr().Interface(), values[0])
+	}
+
+	// ex: []string of "?tag=a&tag=b"
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := parseParam(slice.Index(i).Addr().Interface(), value); err != nil {
+			return err
+		}
+	}
+	field.Set(slice)
+	return nil
+}
@@ -2,10 +2,13 @@ package poteto
 
 import (
 	"bytes"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	validator "github.com/go-playground/validator/v10"
 	"github.com/poteto-go/poteto/constant"
 	"github.com/poteto-go/poteto/perror"
 	"github.com/stretchr/testify/assert"
@@ -148,6 +151,202 @@ func TestBinder_BindWithValidate(t *testing.T) {
 	}
 }
 
+type paginationForTest struct {
+	Page  int `query:"page" default:"1"`
+	Limit int `query:"limit" default:"20"`
+}
+
+type listPostsForTest struct {
+	paginationForTest
+	UserId  int        `param:"id"`
+	Tags    []string   `query:"tag"`
+	Since   *time.Time `query:"since"`
+	Tenant  string     `header:"X-Tenant" validate:"required"`
+	Session string     `cookie:"session"`
+	Title   string     `json:"title" default:"untitled"`
+	Body    string     `json:"body"`
+}
+
+func newBindAllRequestForTest(target, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	if body != "" {
+		req.Header.Set(constant.HeaderContentType, constant.ApplicationJson)
+	}
+	req.Header.Set("X-Tenant", "poteto")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	return req
+}
+
+func newBindAllContextForTest(req *http.Request) Context {
+	ctx := NewContext(httptest.NewRecorder(), req)
+	ctx.SetQueryParam(req.URL.Query())
+	ctx.SetParam(constant.ParamTypePath, ParamUnit{":id", "1"})
+	return ctx
+}
+
+func TestBinder_BindAll(t *testing.T) {
+	binder := NewBinder()
+
+	t.Run("bind all sources", func(t *testing.T) {
+		// Arrange
+		req := newBindAllRequestForTest(
+			"/users/1/posts?page=2&tag=a&tag=b&since=2025-06-01T00:00:00Z",
+			`{"title":"hello", "body":"world"}`,
+		)
+		ctx := newBindAllContextForTest(req)
+		since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		result := listPostsForTest{}
+		err := binder.BindAll(ctx, &result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, listPostsForTest{
+			paginationForTest: paginationForTest{Page: 2, Limit: 20},
+			UserId:            1,
+			Tags:              []string{"a", "b"},
+			Since:             &since,
+			Tenant:            "poteto",
+			Session:           "abc",
+			Title:             "hello",
+			Body:              "world",
+		}, result)
+	})
+
+	t.Run("body is optional", func(t *testing.T) {
+		// Arrange
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/users/1/posts?page=", ""))
+
+		// Act
+		result := listPostsForTest{}
+		err := binder.BindAll(ctx, &result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Page)
+		assert.Equal(t, "untitled", result.Title)
+		assert.Nil(t, result.Since)
+	})
+
+	t.Run("path param overwrites body", func(t *testing.T) {
+		// Arrange
+		type request struct {
+			Id int `json:"id" param:"id"`
+		}
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/users/1", `{"id":2}`))
+
+		// Act
+		result := request{}
+		err := binder.BindAll(ctx, &result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("400 if value is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/users/1/posts?limit=many", ""))
+
+		// Act
+		err := binder.BindAll(ctx, &listPostsForTest{})
+
+		// Assert
+		var httpErr *httpError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, "invalid query param: limit", httpErr.Message)
+	})
+
+	t.Run("validate after bind", func(t *testing.T) {
+		// Arrange
+		req := newBindAllRequestForTest("/users/1/posts", "")
+		req.Header.Del("X-Tenant")
+		ctx := newBindAllContextForTest(req)
+
+		// Act
+		err := binder.BindAll(ctx, &listPostsForTest{})
+
+		// Assert
+		var validationErr validator.ValidationErrors
+		assert.True(t, errors.As(err, &validationErr))
+	})
+
+	t.Run("form body is not decoded", func(t *testing.T) {
+		// Arrange
+		req := newBindAllRequestForTest("/users/1/posts?page=2", "title=hello")
+		req.Header.Set(constant.HeaderContentType, "application/x-www-form-urlencoded")
+		ctx := newBindAllContextForTest(req)
+
+		// Act
+		result := listPostsForTest{}
+		err := binder.BindAll(ctx, &result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, "untitled", result.Title)
+	})
+
+	t.Run("400 if json body is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/users/1/posts", `{"title":`))
+
+		// Act
+		err := binder.BindAll(ctx, &listPostsForTest{})
+
+		// Assert
+		var httpErr *httpError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, "invalid json body", httpErr.Message)
+	})
+
+	t.Run("body is not decoded w/o json field", func(t *testing.T) {
+		// Arrange
+		type request struct {
+			Id int `param:"id"`
+		}
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/users/1", `not json`))
+
+		// Act
+		result := request{}
+		err := binder.BindAll(ctx, &result)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Id)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		// Arrange
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/", ""))
+
+		// Act
+		err := binder.BindAll(ctx, listPostsForTest{})
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrInvalidBindTarget)
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		// Arrange
+		type request struct {
+			Page int `query:"page" default:"first"`
+		}
+		ctx := newBindAllContextForTest(newBindAllRequestForTest("/", ""))
+
+		// Act
+		err := binder.BindAll(ctx, &request{})
+
+		// Assert
+		var httpErr *httpError
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, &httpErr))
+	})
+}
+
 func BenchmarkBind_Bind(b *testing.B) {
 	type User struct {
 		Name string `json:"name"`
@@ -7,7 +7,18 @@ const (
 	MaxDomainLength int = 255
 
 	// path parameter prefix
-	ParamPrefix    string = ":"
+	ParamPrefix string = ":"
+	// catch-all path parameter prefix
+	// EX: /static/*filepath
+	WildcardPrefix string = "*"
+	// path parameter constraint
+	// EX: /users/:id<int>, /users/:id<[0-9]+>
+	ConstraintPrefix string = "<"
+	ConstraintSuffix string = ">"
+	// optional trailing path parameter
+	// EX: /reports/:year/:month?
+	OptionalSuffix string = "?"
+
 	ParamTypePath  string = "path"
 	ParamTypeQuery string = "query"
 
@@ -15,6 +26,16 @@ const (
 	MaxQueryParamCount int = 32
 )
 
+// struct tag of Context.BindAll
+// EX: `param:"id"`, `query:"page" default:"1"`
+const (
+	BindTagParam   string = "param"
+	BindTagQuery   string = "query"
+	BindTagHeader  string = "header"
+	BindTagCookie  string = "cookie"
+	BindTagDefault string = "default"
+)
+
 const (
 	AlgorithmHS256 = "HS256"
 	AuthScheme     = "Bearer"
@@ -42,6 +63,21 @@ const (
 	HeaderRequestId           string = "X-Request-Id"
 	HeaderXForwardedFor       string = "X-Forwarded-For"
 	HeaderXRealIp             string = "X-Real-Ip"
+	HeaderAllow               string = "Allow"
+	HeaderLocation            string = "Location"
+	HeaderAcceptEncoding      string = "Accept-Encoding"
+	HeaderContentEncoding     string = "Content-Encoding"
+	HeaderETag                string = "ETag"
+)
+
+// Path Policy
+const (
+	// no cleaning: "/users/" & "//users" do not match "/users"
+	PathPolicyStrict string = "strict"
+	// redirect to canonical path: GET & HEAD -> 301, others -> 308
+	PathPolicyRedirect string = "redirect"
+	// route canonical path w/o redirect
+	PathPolicyLenient string = "lenient"
 )
 
 // Workflow
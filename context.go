@@ -1,15 +1,19 @@
 package poteto
 
 import (
+	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/harakeishi/gats"
 	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 	"github.com/poteto-go/poteto/utils"
 )
 
@@ -46,6 +50,26 @@ type Context interface {
 	// }
 	BindWithValidate(object any) error
 
+	// Bind path, query, header, cookie & json body
+	// then validate w/ github.com/go-playground/validator/v10
+	//
+	// type ListPosts struct {
+	//   UserId  int      `param:"id"`
+	//   Page    int      `query:"page" default:"1"`
+	//   Tags    []string `query:"tag"`
+	//   Tenant  string   `header:"X-Tenant" validate:"required"`
+	//   Session string   `cookie:"session"`
+	//   Title   string   `json:"title"`
+	// }
+	//
+	// func handler(ctx poteto.Context) error {
+	//   req := ListPosts{}
+	//   if err := ctx.BindAll(&req); err != nil {
+	//     return err
+	//   }
+	// }
+	BindAll(object any) error
+
 	WriteHeader(code int)
 
 	JsonSerialize(value any) error
@@ -69,6 +93,24 @@ type Context interface {
 	// }
 	QueryParam(key string) (string, bool)
 
+	// Get all values of query parameter
+	// values of bracket notation are included
+	//
+	// "?tag=a&tag=b" -> ctx.QueryParams("tag") -> ["a", "b"]
+	// "?ids[]=1&ids[]=2" -> ctx.QueryParams("ids") -> ["1", "2"]
+	QueryParams(key string) []string
+
+	// Get query parameters of bracket notation as map
+	// first value is used if repeated
+	//
+	// "?filter[status]=open&filter[owner]=me"
+	// -> ctx.QueryMap("filter") -> {"status": "open", "owner": "me"}
+	QueryMap(key string) map[string]string
+
+	// Get raw query parameters
+	// do not modify
+	QueryValues() url.Values
+
 	// DebugParam return all http parameters
 	//
 	// use for debug or log
@@ -160,6 +202,30 @@ type Context interface {
 
 	// get logger
 	Logger() any
+
+	// build url of the route named by poteto.WithName
+	// params are key-value pairs
+	//
+	// func handler(ctx poteto.Context) error {
+	//   url, err := ctx.URL("user", "id", "1") // -> "/users/1"
+	// }
+	URL(name string, params ...string) (string, error)
+
+	// matched route of the request
+	// zero value if no route is matched
+	//
+	// func middleware(next poteto.HandlerFunc) poteto.HandlerFunc {
+	//   return func(ctx poteto.Context) error {
+	//     route := ctx.Route() // -> Pattern: "/users/:id"
+	//     scope, ok := route.Get("scope")
+	//     ...
+	//   }
+	// }
+	Route() MatchedRoute
+
+	// true if path is routed w/ case folding
+	// ex: PotetoOption.CaseInsensitive
+	IsCaseInsensitive() bool
 }
 
 type context struct {
@@ -171,6 +237,10 @@ type context struct {
 	store      map[string]any
 	logger     any
 	lock       sync.RWMutex
+	router     Router
+	route      *route
+	// raw query params set by SetQueryParam
+	query url.Values
 
 	// Method
 	binder Binder
@@ -215,6 +285,8 @@ func (ctx *context) SetPath(path string) {
 }
 
 func (ctx *context) SetQueryParam(queryParams url.Values) {
+	// raw values are kept even if too many
+	ctx.query = queryParams
 	if len(queryParams) > constant.MaxQueryParamCount {
 		utils.PotetoPrint("too many query params should be < 32\n")
 		return
@@ -253,6 +325,41 @@ func (ctx *context) QueryParam(key string) (string, bool) {
 	return ctx.httpParams.GetQueryParam(key)
 }
 
+func (ctx *context) QueryParams(key string) []string {
+	return slices.Concat(ctx.query[key], ctx.query[key+"[]"])
+}
+
+func (ctx *context) QueryMap(key string) map[string]string {
+	queryMap := map[string]string{}
+	for name, values := range ctx.query {
+		field, ok := bracketField(name, key)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		queryMap[field] = values[0]
+	}
+	return queryMap
+}
+
+func (ctx *context) QueryValues() url.Values {
+	return ctx.query
+}
+
+// "filter[status]", "filter" -> "status", true
+// nested field is not supported ex: "filter[a][b]"
+func bracketField(name, key string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, key+"[")
+	if !ok {
+		return "", false
+	}
+
+	field, ok := strings.CutSuffix(rest, "]")
+	if !ok || field == "" || strings.ContainsAny(field, "[]") {
+		return "", false
+	}
+	return field, true
+}
+
 func (ctx *context) Bind(object any) error {
 	return ctx.binder.Bind(ctx, object)
 }
@@ -261,6 +368,10 @@ func (ctx *context) BindWithValidate(object any) error {
 	return ctx.binder.BindWithValidate(ctx, object)
 }
 
+func (ctx *context) BindAll(object any) error {
+	return ctx.binder.BindAll(ctx, object)
+}
+
 func (ctx *context) DebugParam() (string, bool) {
 	val, err := ctx.httpParams.JsonSerialize()
 	if err != nil {
@@ -377,6 +488,8 @@ func (ctx *context) Reset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx.path = ""
+	ctx.route = nil
+	ctx.query = nil
 
 	// loggerはリセットない
 }
@@ -388,3 +501,31 @@ func (ctx *context) SetLogger(logger any) {
 func (ctx *context) Logger() any {
 	return ctx.logger
 }
+
+func (ctx *context) URL(name string, params ...string) (string, error) {
+	if ctx.router == nil {
+		return "", fmt.Errorf("%w: %s", perror.ErrRouteNameNotFound, name)
+	}
+
+	return ctx.router.URL(name, params...)
+}
+
+func (ctx *context) IsCaseInsensitive() bool {
+	return ctx.router != nil && ctx.router.isCaseInsensitive()
+}
+
+func (ctx *context) Route() MatchedRoute {
+	if ctx.route == nil {
+		return MatchedRoute{}
+	}
+
+	matched := MatchedRoute{
+		Pattern:  ctx.route.pattern,
+		Name:     ctx.route.name,
+		Metadata: ctx.route.metadata,
+	}
+	if ctx.request != nil {
+		matched.Method = ctx.request.Method
+	}
+	return matched
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/agiledragon/gomonkey"
 	"github.com/google/uuid"
 	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -184,6 +185,7 @@ func TestContext_SetQueryParam(t *testing.T) {
 
 			// Assert
 			assert.Equal(t, len(ctx.httpParams.(*httpParam).QueryParams), it.expectedCount)
+			assert.Equal(t, it.queryParams, ctx.QueryValues())
 
 			for key, value := range it.expected {
 				actualValue, ok := ctx.httpParams.GetParam(constant.ParamTypeQuery, key)
@@ -271,6 +273,71 @@ func TestContext_QueryParam(t *testing.T) {
 	assert.Equal(t, true, ok)
 }
 
+func TestContext_QueryParams(t *testing.T) {
+	// Arrange
+	ctx := NewContext(nil, nil).(*context)
+	ctx.SetQueryParam(url.Values{
+		"tag":   {"a", "b"},
+		"text":  {"hello, world"},
+		"ids[]": {"1", "2"},
+		"ids":   {"0"},
+	})
+
+	tests := []struct {
+		name     string
+		key      string
+		expected []string
+	}{
+		{"repeated values", "tag", []string{"a", "b"}},
+		{"value w/ comma", "text", []string{"hello, world"}},
+		{"bracket notation", "ids", []string{"0", "1", "2"}},
+		{"not found", "unknown", nil},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := ctx.QueryParams(it.key)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestContext_QueryMap(t *testing.T) {
+	// Arrange
+	ctx := NewContext(nil, nil).(*context)
+	ctx.SetQueryParam(url.Values{
+		"filter[status]": {"open", "closed"},
+		"filter[owner]":  {"me"},
+		"filter[]":       {"ignored"},
+		"filter[a][b]":   {"ignored"},
+		"filters[x]":     {"ignored"},
+		"filter":         {"ignored"},
+	})
+
+	// Act
+	result := ctx.QueryMap("filter")
+	notFound := ctx.QueryMap("unknown")
+
+	// Assert
+	assert.Equal(t, map[string]string{"status": "open", "owner": "me"}, result)
+	assert.Equal(t, map[string]string{}, notFound)
+}
+
+func TestContext_QueryValues(t *testing.T) {
+	// Arrange
+	ctx := NewContext(nil, nil).(*context)
+	values := url.Values{"tag": {"a", "b"}}
+
+	// Act
+	ctx.SetQueryParam(values)
+
+	// Assert
+	assert.Equal(t, values, ctx.QueryValues())
+}
+
 func TestContext_Bind(t *testing.T) {
 	// Arrange
 	ctx := NewContext(nil, nil).(*context)
@@ -329,6 +396,35 @@ func TestContext_BindWithValidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestContext_BindAll(t *testing.T) {
+	// Arrange
+	ctx := NewContext(nil, nil).(*context)
+	type User struct {
+		Id int `param:"id"`
+	}
+	user := User{}
+
+	// Mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+
+	patches.ApplyMethod(
+		reflect.TypeOf(ctx.binder),
+		"BindAll",
+		func(_ *binder, c Context, obj any) error {
+			assert.Equal(t, c, ctx)
+			assert.Equal(t, &user, obj)
+			return nil
+		},
+	)
+
+	// Act
+	err := ctx.BindAll(&user)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
 func TestContext_DebugParam(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -946,6 +1042,7 @@ func TestContext_Reset(t *testing.T) {
 	// check query params
 	_, ok := ctx.QueryParam("old")
 	assert.False(t, ok)
+	assert.Nil(t, ctx.QueryValues())
 	// check store
 	_, ok = ctx.Get("test")
 	assert.False(t, ok)
@@ -963,3 +1060,64 @@ func TestContext_Logger(t *testing.T) {
 	// Assert
 	assert.NotNil(t, result)
 }
+
+func TestContext_URL(t *testing.T) {
+	t.Run("build url w/ router", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter()
+		rtr.GET("/users/:id", nil, WithName("user"))
+		ctx := NewContext(nil, nil).(*context)
+		ctx.router = rtr
+
+		// Act
+		url, err := ctx.URL("user", "id", "1")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "/users/1", url)
+	})
+
+	t.Run("error w/o router", func(t *testing.T) {
+		// Arrange
+		ctx := NewContext(nil, nil)
+
+		// Act
+		_, err := ctx.URL("user", "id", "1")
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrRouteNameNotFound)
+	})
+}
+
+func TestContext_Route(t *testing.T) {
+	t.Run("matched route", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter()
+		rtr.GET("/users/:id", nil, WithName("user"), WithMetadata("scope", "admin"))
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		ctx := NewContext(nil, req).(*context)
+		ctx.route, _ = rtr.GetRoutesByMethod(http.MethodGet).Search("/users/1")
+
+		// Act
+		result := ctx.Route()
+
+		// Assert
+		assert.Equal(t, MatchedRoute{
+			Method:   http.MethodGet,
+			Pattern:  "/users/:id",
+			Name:     "user",
+			Metadata: map[string]any{"scope": "admin"},
+		}, result)
+	})
+
+	t.Run("zero value if not matched", func(t *testing.T) {
+		// Arrange
+		ctx := NewContext(nil, nil)
+
+		// Act
+		result := ctx.Route()
+
+		// Assert
+		assert.Equal(t, MatchedRoute{}, result)
+	})
+}
@@ -33,3 +33,15 @@ func DefaultErrorHandler(err error, ctx Context) {
 	err = ctx.JSON(httpErr.Code, message)
 	_ = err
 }
+
+// This is default handler called if no route is matched
+func DefaultNotFoundHandler(ctx Context) error {
+	ctx.WriteHeader(http.StatusNotFound)
+	return nil
+}
+
+// This is default handler called if path exists only under other methods
+// response is made by ErrorHandler
+func DefaultMethodNotAllowedHandler(ctx Context) error {
+	return NewHttpError(http.StatusMethodNotAllowed)
+}
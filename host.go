@@ -0,0 +1,93 @@
+package poteto
+
+import (
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/poteto-go/poteto/constant"
+)
+
+// routes served only on matched host
+//
+// EX: "admin.example.com", ":tenant.example.com"
+type hostRoute struct {
+	pattern string
+	// lower-cased labels split by "."
+	labels   []string
+	hasParam bool
+	app      *poteto
+}
+
+func newHostRoute(pattern string, app *poteto) *hostRoute {
+	pattern = normalizeHost(pattern)
+	labels := strings.Split(pattern, ".")
+
+	return &hostRoute{
+		pattern: pattern,
+		labels:  labels,
+		hasParam: slices.ContainsFunc(labels, func(label string) bool {
+			return hasParamPrefix(label)
+		}),
+		app: app,
+	}
+}
+
+// match normalized host label by label
+// ":tenant" label captures one label as path param
+//
+// ":tenant.example.com" & "acme.example.com" -> {":tenant": "acme"}
+func (h *hostRoute) match(host string) ([]ParamUnit, bool) {
+	labels := strings.Split(host, ".")
+	if len(labels) != len(h.labels) {
+		return nil, false
+	}
+
+	hostParams := []ParamUnit{}
+	for i, label := range h.labels {
+		if hasParamPrefix(label) {
+			if labels[i] == "" {
+				return nil, false
+			}
+			hostParams = append(hostParams, ParamUnit{key: label, value: labels[i]})
+			continue
+		}
+
+		if label != labels[i] {
+			return nil, false
+		}
+	}
+	return hostParams, true
+}
+
+// "Admin.Example.com:8080" -> "admin.example.com"
+// "example.com." -> "example.com"
+func normalizeHost(host string) string {
+	// ":tenant.example.com" is not host w/ port
+	if hostname, port, err := net.SplitHostPort(host); err == nil && isPort(port) {
+		host = hostname
+	}
+
+	host = strings.TrimSuffix(host, ".")
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		// keep case of param key
+		if !strings.HasPrefix(label, constant.ParamPrefix) {
+			labels[i] = strings.ToLower(label)
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+func isPort(port string) bool {
+	if port == "" {
+		return false
+	}
+
+	for _, c := range port {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
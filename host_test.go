@@ -0,0 +1,60 @@
+package poteto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostRoute_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		host     string
+		expected []ParamUnit
+		matched  bool
+	}{
+		{"static host", "admin.example.com", "admin.example.com", []ParamUnit{}, true},
+		{"unmatched static host", "admin.example.com", "api.example.com", nil, false},
+		{"param host", ":tenant.example.com", "acme.example.com", []ParamUnit{{":tenant", "acme"}}, true},
+		{"param needs same label count", ":tenant.example.com", "example.com", nil, false},
+		{"empty label", ":tenant.example.com", ".example.com", nil, false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			hr := newHostRoute(it.pattern, nil)
+
+			// Act
+			result, ok := hr.match(it.host)
+
+			// Assert
+			assert.Equal(t, it.matched, ok)
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		expected string
+	}{
+		{"lower case", "Admin.Example.COM", "admin.example.com"},
+		{"w/ port", "admin.example.com:8080", "admin.example.com"},
+		{"trailing dot", "example.com.", "example.com"},
+		{"keep param key", ":tenantId.Example.com", ":tenantId.example.com"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := normalizeHost(it.host)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
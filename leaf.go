@@ -1,6 +1,11 @@
 package poteto
 
 import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/poteto-go/poteto/perror"
 	"github.com/poteto-go/poteto/utils"
 )
@@ -13,44 +18,93 @@ import (
 /   leaf.POST("/create", getAllUserForTest)
 /   leaf.PUT("/change", getAllUserForTest)
 /   leaf.DELETE("/delete", getAllUserForTest)
+/
+/   leaf.Leaf("/admin", func(admin Leaf) {
+/     admin.Register(authMiddleware)
+/     admin.GET("/", getAdminForTest)
+/   })
 / })
 */
 
 type leaf struct {
 	poteto   Poteto
+	parent   *leaf
 	basePath string
+	metadata map[string]any
+	// registered on this leaf
+	// middlewares of parent are resolved by allMiddlewares
+	middlewares  []MiddlewareFunc
+	errorHandler ErrorHandlerFunc
+	// registered on this leaf & its sub leaves
+	routes []leafRoute
+}
+
+type leafRoute struct {
+	method string
+	path   string
+	// leaf the route is registered on
+	leaf *leaf
 }
 
 type Leaf interface {
-	// internal call Poteto.Combine w/ base path
-	Register(middlewares ...MiddlewareFunc) *middlewareTree
+	// apply middlewares to routes of this leaf & its sub leaves
+	// routes registered before the call are also applied
+	// other routes sharing the base path are not affected
+	//
+	// it no longer returns *middlewareTree
+	// use Poteto.Combine to apply middlewares to all routes of the path
+	Register(middlewares ...MiddlewareFunc)
+
+	// handle error of routes registered after on this leaf & its sub leaves
+	// it is prior to Poteto.SetErrorHandler
+	SetErrorHandler(handler ErrorHandlerFunc)
+
+	// make sub leaf w/ base path joined
+	// sub leaf inherits middlewares, metadata & error handler set so far
+	//
+	// p.Leaf("/api", func(api Leaf) {
+	//   api.Leaf("/v2", func(v2 Leaf) {
+	//     v2.GET("/users", handler) // -> "/api/v2/users"
+	//   })
+	// })
+	Leaf(basePath string, yield LeafHandler)
+
+	// attach metadata to routes registered after on this leaf
+	// poteto.WithMetadata of each route overrides it
+	SetMetadata(key string, value any)
 
 	// internal call Poteto.GET w/ base path
-	GET(addPath string, handler HandlerFunc) error
+	GET(addPath string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.POST w/ base path
-	POST(addPath string, handler HandlerFunc) error
+	POST(addPath string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.PUT w/ base path
-	PUT(addPath string, handler HandlerFunc) error
+	PUT(addPath string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.PATCH w/ base path
-	PATCH(path string, handler HandlerFunc) error
+	PATCH(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.DELETE w/ base path
-	DELETE(addPath string, handler HandlerFunc) error
+	DELETE(addPath string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.HEAD w/ base path
-	HEAD(path string, handler HandlerFunc) error
+	HEAD(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.OPTIONS w/ base path
-	OPTIONS(path string, handler HandlerFunc) error
+	OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.TRACE w/ base path
-	TRACE(path string, handler HandlerFunc) error
+	TRACE(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	// internal call Poteto.CONNECT w/ base path
-	CONNECT(path string, handler HandlerFunc) error
+	CONNECT(path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// internal call Poteto.Handle w/ base path
+	Handle(method, addPath string, handler HandlerFunc, opts ...RouteOption) error
+
+	// internal call Poteto.Any w/ base path
+	Any(addPath string, handler HandlerFunc, opts ...RouteOption) error
 }
 
 func NewLeaf(poteto Poteto, basePath string) Leaf {
@@ -60,71 +114,155 @@ func NewLeaf(poteto Poteto, basePath string) Leaf {
 	}
 }
 
-func (l *leaf) Register(middlewares ...MiddlewareFunc) *middlewareTree {
-	return l.poteto.Combine(l.basePath, middlewares...)
+func (l *leaf) Register(middlewares ...MiddlewareFunc) {
+	l.middlewares = append(l.middlewares, middlewares...)
+	for _, lr := range l.routes {
+		// route removed after registration is skipped
+		l.poteto.setRouteMiddlewares(lr.method, lr.path, lr.leaf.allMiddlewares())
+	}
 }
 
-func (l *leaf) GET(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, GET{}, addPath, handler)
+// middlewares of parent leaves first
+func (l *leaf) allMiddlewares() []MiddlewareFunc {
+	if l.parent == nil {
+		return slices.Clone(l.middlewares)
+	}
+	return slices.Concat(l.parent.allMiddlewares(), l.middlewares)
 }
 
-func (l *leaf) POST(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, POST{}, addPath, handler)
+// record route so that middlewares registered after are applied
+func (l *leaf) addRoute(method, path string) {
+	for owner := l; owner != nil; owner = owner.parent {
+		owner.routes = append(owner.routes, leafRoute{method: method, path: path, leaf: l})
+	}
 }
 
-func (l *leaf) PUT(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, PUT{}, addPath, handler)
+func (l *leaf) SetErrorHandler(handler ErrorHandlerFunc) {
+	l.errorHandler = handler
+}
+
+func (l *leaf) Leaf(basePath string, yield LeafHandler) {
+	// ".." is rejected on route registration
+	sub := &leaf{
+		poteto:       l.poteto,
+		parent:       l,
+		basePath:     strings.TrimSuffix(l.basePath, "/") + "/" + strings.TrimPrefix(basePath, "/"),
+		metadata:     maps.Clone(l.metadata),
+		errorHandler: l.errorHandler,
+	}
+
+	yield(sub)
+}
+
+func (l *leaf) SetMetadata(key string, value any) {
+	if l.metadata == nil {
+		l.metadata = map[string]any{}
+	}
+	l.metadata[key] = value
+}
+
+// leaf options are applied first
+// so that route's option overrides it
+func (l *leaf) routeOptions(opts []RouteOption) []RouteOption {
+	leafOpts := []RouteOption{}
+	if len(l.metadata) != 0 {
+		leafOpts = append(leafOpts, withMetadataMap(l.metadata))
+	}
+	if middlewares := l.allMiddlewares(); len(middlewares) != 0 {
+		leafOpts = append(leafOpts, withMiddlewares(middlewares))
+	}
+	if l.errorHandler != nil {
+		leafOpts = append(leafOpts, withErrorHandler(l.errorHandler))
+	}
+	return append(leafOpts, opts...)
 }
 
-func (l *leaf) PATCH(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, PATCH{}, addPath, handler)
+func (l *leaf) GET(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, GET{}, addPath, handler, opts...)
 }
 
-func (l *leaf) DELETE(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, DELETE{}, addPath, handler)
+func (l *leaf) POST(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, POST{}, addPath, handler, opts...)
 }
 
-func (l *leaf) HEAD(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, HEAD{}, addPath, handler)
+func (l *leaf) PUT(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, PUT{}, addPath, handler, opts...)
 }
 
-func (l *leaf) OPTIONS(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, OPTIONS{}, addPath, handler)
+func (l *leaf) PATCH(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, PATCH{}, addPath, handler, opts...)
 }
 
-func (l *leaf) TRACE(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, TRACE{}, addPath, handler)
+func (l *leaf) DELETE(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, DELETE{}, addPath, handler, opts...)
 }
 
-func (l *leaf) CONNECT(addPath string, handler HandlerFunc) error {
-	return leafAdd(l, CONNECT{}, addPath, handler)
+func (l *leaf) HEAD(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, HEAD{}, addPath, handler, opts...)
 }
 
-func leafAdd[M HTTPMethod](l *leaf, method M, addPath string, handler HandlerFunc) error {
+func (l *leaf) OPTIONS(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, OPTIONS{}, addPath, handler, opts...)
+}
+
+func (l *leaf) TRACE(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, TRACE{}, addPath, handler, opts...)
+}
+
+func (l *leaf) CONNECT(addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	return leafAdd(l, CONNECT{}, addPath, handler, opts...)
+}
+
+func (l *leaf) Handle(method, addPath string, handler HandlerFunc, opts ...RouteOption) error {
+	path, err := utils.BuildSafeUrl(l.basePath, addPath)
+	if err != nil {
+		return err
+	}
+
+	if err := l.poteto.Handle(method, path, handler, l.routeOptions(opts)...); err != nil {
+		return err
+	}
+
+	l.addRoute(method, path)
+	return nil
+}
+
+func (l *leaf) Any(addPath string, handler HandlerFunc, opts ...RouteOption) error {
 	path, err := utils.BuildSafeUrl(l.basePath, addPath)
 	if err != nil {
 		return err
 	}
 
+	if err := l.poteto.Any(path, handler, l.routeOptions(opts)...); err != nil {
+		return err
+	}
+
+	for _, method := range allHttpMethods {
+		l.addRoute(method, path)
+	}
+	return nil
+}
+
+func leafAdd[M HTTPMethod](l *leaf, method M, addPath string, handler HandlerFunc, opts ...RouteOption) error {
 	switch any(method).(type) {
 	case GET:
-		return l.poteto.GET(path, handler)
+		return l.Handle(http.MethodGet, addPath, handler, opts...)
 	case POST:
-		return l.poteto.POST(path, handler)
+		return l.Handle(http.MethodPost, addPath, handler, opts...)
 	case PUT:
-		return l.poteto.PUT(path, handler)
+		return l.Handle(http.MethodPut, addPath, handler, opts...)
 	case PATCH:
-		return l.poteto.PATCH(path, handler)
+		return l.Handle(http.MethodPatch, addPath, handler, opts...)
 	case DELETE:
-		return l.poteto.DELETE(path, handler)
+		return l.Handle(http.MethodDelete, addPath, handler, opts...)
 	case HEAD:
-		return l.poteto.HEAD(path, handler)
+		return l.Handle(http.MethodHead, addPath, handler, opts...)
 	case OPTIONS:
-		return l.poteto.OPTIONS(path, handler)
+		return l.Handle(http.MethodOptions, addPath, handler, opts...)
 	case TRACE:
-		return l.poteto.TRACE(path, handler)
+		return l.Handle(http.MethodTrace, addPath, handler, opts...)
 	case CONNECT:
-		return l.poteto.CONNECT(path, handler)
+		return l.Handle(http.MethodConnect, addPath, handler, opts...)
 	default:
 		// not run
 		return perror.ErrUnSupportedHTTPMethod
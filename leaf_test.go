@@ -1,9 +1,12 @@
 package poteto
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLeaf(t *testing.T) {
@@ -118,3 +121,153 @@ func TestLeaf(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaf_Leaf(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/api/v2/other", getAllUserForTest)
+	p.Leaf("/api", func(api Leaf) {
+		api.Register(sampleMiddleware)
+		api.Leaf("/v2", func(v2 Leaf) {
+			v2.Register(sampleMiddleware2)
+			v2.GET("/users", getAllUserForTest)
+		})
+		api.Leaf("/v1", func(v1 Leaf) {
+			v1.GET("/users", getAllUserForTest)
+		})
+		api.GET("/health", getAllUserForTest)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedHello  string
+		expectedHello2 string
+	}{
+		{"sub leaf w/ parent & own middlewares", "/api/v2/users", "world", "world2"},
+		{"sibling sub leaf w/o middleware of other", "/api/v1/users", "world", ""},
+		{"parent leaf w/o middleware of sub leaf", "/api/health", "world", ""},
+		{"route sharing base path w/o middleware", "/api/v2/other", "", ""},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(http.MethodGet, it.path)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, it.expectedHello, res.Header().Get("Hello"))
+			assert.Equal(t, it.expectedHello2, res.Header().Get("Hello2"))
+		})
+	}
+}
+
+func TestLeaf_RegisterAfterRoutes(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Leaf("/api", func(api Leaf) {
+		api.Leaf("/v2", func(v2 Leaf) {
+			v2.GET("/users", getAllUserForTest)
+			v2.Register(sampleMiddleware2)
+		})
+		api.GET("/health", getAllUserForTest)
+		api.Any("/any", getAllUserForTest)
+		api.Register(sampleMiddleware)
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedHello  string
+		expectedHello2 string
+	}{
+		{"route registered before", http.MethodGet, "/api/health", "world", ""},
+		{"route of Any registered before", http.MethodPost, "/api/any", "world", ""},
+		{"route of sub leaf registered before", http.MethodGet, "/api/v2/users", "world", "world2"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(it.method, it.path)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, it.expectedHello, res.Header().Get("Hello"))
+			assert.Equal(t, it.expectedHello2, res.Header().Get("Hello2"))
+		})
+	}
+}
+
+func TestLeaf_SetErrorHandler(t *testing.T) {
+	// Arrange
+	errHandler := func(code int) ErrorHandlerFunc {
+		return func(err error, ctx Context) {
+			ctx.WriteHeader(code)
+		}
+	}
+	failHandler := func(ctx Context) error {
+		return errors.New("failed")
+	}
+
+	p := New()
+	p.SetErrorHandler(errHandler(http.StatusInternalServerError))
+	p.GET("/fail", failHandler)
+	p.Leaf("/users", func(users Leaf) {
+		users.SetErrorHandler(errHandler(http.StatusTeapot))
+		users.GET("/fail", failHandler)
+		users.Leaf("/admin", func(admin Leaf) {
+			admin.GET("/fail", failHandler)
+		})
+		users.Leaf("/items", func(items Leaf) {
+			items.SetErrorHandler(errHandler(http.StatusConflict))
+			items.GET("/fail", failHandler)
+		})
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		{"app error handler", "/fail", http.StatusInternalServerError},
+		{"leaf error handler", "/users/fail", http.StatusTeapot},
+		{"inherited error handler", "/users/admin/fail", http.StatusTeapot},
+		{"sub leaf error handler", "/users/items/fail", http.StatusConflict},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(http.MethodGet, it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, res.Code)
+		})
+	}
+}
+
+func TestLeaf_AddApi(t *testing.T) {
+	// Arrange
+	p := New()
+	api := Api("/users", func(leaf Leaf) {
+		leaf.Register(sampleMiddleware)
+		leaf.SetErrorHandler(func(err error, ctx Context) {
+			ctx.WriteHeader(http.StatusTeapot)
+		})
+		leaf.GET("/fail", func(ctx Context) error {
+			return errors.New("failed")
+		})
+	})
+
+	// Act
+	err := p.AddApi(api)
+	res := p.Play(http.MethodGet, "/users/fail")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTeapot, res.Code)
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+}
@@ -0,0 +1,42 @@
+package poteto
+
+// Route matched to the request
+//
+// returned by Context.Route
+type MatchedRoute struct {
+	Method string
+	// registered pattern
+	// EX: "/users/:id"
+	Pattern string
+	// named by poteto.WithName
+	Name string
+	// attached by poteto.WithMetadata & Leaf.SetMetadata
+	// shared by all requests, do not modify
+	Metadata map[string]any
+}
+
+func (mr MatchedRoute) Get(key string) (any, bool) {
+	value, ok := mr.Metadata[key]
+	return value, ok
+}
+
+// get metadata of matched route as T
+// return false if not found or type is unmatched
+//
+//	p.GET("/admin", handler, poteto.WithMetadata("scopes", []string{"admin"}))
+//
+//	scopes, ok := poteto.RouteMetadataAs[[]string](ctx, "scopes")
+func RouteMetadataAs[T any](ctx Context, key string) (T, bool) {
+	var zero T
+
+	value, ok := ctx.Route().Get(key)
+	if !ok {
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return zero, false
+	}
+	return typed, true
+}
@@ -0,0 +1,51 @@
+package poteto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchedRoute_Get(t *testing.T) {
+	// Arrange
+	mr := MatchedRoute{Metadata: map[string]any{"scope": "admin"}}
+
+	// Act
+	value, ok := mr.Get("scope")
+	_, notOk := MatchedRoute{}.Get("scope")
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "admin", value)
+	assert.False(t, notOk)
+}
+
+func TestRouteMetadataAs(t *testing.T) {
+	// Arrange
+	rtr := NewRouter()
+	rtr.GET("/admin", nil, WithMetadata("scopes", []string{"admin"}), WithMetadata("rate", 10))
+	ctx := NewContext(nil, nil).(*context)
+	ctx.route, _ = rtr.GetRoutesByMethod("GET").Search("/admin")
+
+	tests := []struct {
+		name     string
+		key      string
+		expected []string
+		ok       bool
+	}{
+		{"typed value", "scopes", []string{"admin"}, true},
+		{"unmatched type", "rate", nil, false},
+		{"not found", "owner", nil, false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result, ok := RouteMetadataAs[[]string](ctx, it.key)
+
+			// Assert
+			assert.Equal(t, it.ok, ok)
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
@@ -6,12 +6,13 @@ import (
 )
 
 type CamaraConfig struct {
-	ContentSecurityPolicy   string `yaml:"content_security_policy"`
-	XFrameOption            string `yaml:"x_frame_option"`
-	StrictTransportSecurity string `yaml:"strict_transport_security"`
-	XDownloadOption         string `yaml:"x_download_option"`
-	XContentTypeOption      string `yaml:"x_content_type_option"`
-	ReferrerPolicy          string `yaml:"referrer_policy"`
+	ContentSecurityPolicy   string  `yaml:"content_security_policy"`
+	XFrameOption            string  `yaml:"x_frame_option"`
+	StrictTransportSecurity string  `yaml:"strict_transport_security"`
+	XDownloadOption         string  `yaml:"x_download_option"`
+	XContentTypeOption      string  `yaml:"x_content_type_option"`
+	ReferrerPolicy          string  `yaml:"referrer_policy"`
+	Skipper                 Skipper `yaml:"-"`
 }
 
 var DefaultCamaraConfig = CamaraConfig{
@@ -21,6 +22,7 @@ var DefaultCamaraConfig = CamaraConfig{
 	XDownloadOption:         "noopen",
 	XContentTypeOption:      "nosniff",
 	ReferrerPolicy:          "no-referrer",
+	Skipper:                 DefaultSkipper,
 }
 
 // Provide Some Security Header
@@ -49,8 +51,16 @@ func CamaraWithConfig(config CamaraConfig) poteto.MiddlewareFunc {
 		config.ReferrerPolicy = DefaultCamaraConfig.ReferrerPolicy
 	}
 
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if config.Skipper(ctx) {
+				return next(ctx)
+			}
+
 			// * XXS
 			// CSP Header
 			ctx.SetResponseHeader(
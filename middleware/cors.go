@@ -12,11 +12,13 @@ import (
 type CORSConfig struct {
 	AllowOrigins []string `yaml:"allow_origins"`
 	AllowMethods []string `yaml:"allow_methods"`
+	Skipper      Skipper  `yaml:"-"`
 }
 
 var DefaultCORSConfig = CORSConfig{
 	AllowOrigins: []string{"*"},
 	AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+	Skipper:      DefaultSkipper,
 }
 
 func CORSWithConfig(config CORSConfig) poteto.MiddlewareFunc {
@@ -28,6 +30,10 @@ func CORSWithConfig(config CORSConfig) poteto.MiddlewareFunc {
 		config.AllowMethods = DefaultCORSConfig.AllowMethods
 	}
 
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
 	allowOriginPatterns := make([]string, len(config.AllowOrigins))
 	for i, origin := range config.AllowOrigins {
 		pattern := wrapRegExp(origin)
@@ -36,6 +42,10 @@ func CORSWithConfig(config CORSConfig) poteto.MiddlewareFunc {
 
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if config.Skipper(ctx) {
+				return next(ctx)
+			}
+
 			req := ctx.GetRequest()
 			res := ctx.GetResponse()
 			origin := req.Header.Get(constant.HeaderOrigin)
@@ -16,6 +16,7 @@ type PotetoJWSConfig struct {
 	SignKey    any
 	ContextKey string
 	ClaimsFunc func(c poteto.Context) jwt.Claims
+	Skipper    Skipper
 }
 
 type IPotetoJWSConfig interface {
@@ -30,6 +31,7 @@ var DefaultJWSConfig = &PotetoJWSConfig{
 	ClaimsFunc: func(c poteto.Context) jwt.Claims {
 		return jwt.MapClaims{}
 	},
+	Skipper: DefaultSkipper,
 }
 
 func (cfg *PotetoJWSConfig) KeyFunc(token *jwt.Token) (any, error) {
@@ -61,8 +63,18 @@ func JWSWithConfig(cfg IPotetoJWSConfig) poteto.MiddlewareFunc {
 		panic(config.SignKey)
 	}
 
+	// config is not modified since it is shared w/ caller
+	skipper := config.Skipper
+	if skipper == nil {
+		skipper = DefaultSkipper
+	}
+
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if skipper(ctx) {
+				return next(ctx)
+			}
+
 			authValue, err := extractBearer(ctx)
 			if err != nil {
 				return poteto.NewHttpError(http.StatusBadRequest, err)
@@ -22,6 +22,7 @@ type OidcConfig struct {
 	// you can set custom verify signature callback
 	CustomVerifyTokenSignature func(idToken oidc.IdToken, jwksUrl string) error                      `yaml:"-"`
 	CachedVerifyTokenSignature func(idToken oidc.IdToken, pCache *cache.Cache, jwksUrl string) error `yaml:"-"`
+	Skipper                    Skipper                                                               `yaml:"-"`
 }
 
 var OidcWithoutVerifyConfig = OidcConfig{
@@ -34,6 +35,7 @@ var OidcWithoutVerifyConfig = OidcConfig{
 	CacheMode:                  false,
 	Cache:                      nil,
 	CustomVerifyTokenSignature: nil,
+	Skipper:                    DefaultSkipper,
 }
 
 var DefaultOidcConfig = OidcConfig{
@@ -47,6 +49,7 @@ var DefaultOidcConfig = OidcConfig{
 	Cache:                      nil,
 	CustomVerifyTokenSignature: oidc.DefaultVerifyTokenSignature,
 	CachedVerifyTokenSignature: oidc.CachedVerifyTokenSignature,
+	Skipper:                    DefaultSkipper,
 }
 
 // Oidc verify signature by jwks url & set token -> context
@@ -100,8 +103,16 @@ func OidcWithConfig(cfg OidcConfig) poteto.MiddlewareFunc {
 		cfg.Cache = cache.New(cfg.DefaultExpiration, cfg.CleanupInterval)
 	}
 
+	if cfg.Skipper == nil {
+		cfg.Skipper = DefaultSkipper
+	}
+
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if cfg.Skipper(ctx) {
+				return next(ctx)
+			}
+
 			authValue, err := extractBearer(ctx)
 			if err != nil {
 				return err
@@ -47,6 +47,7 @@ type RequestLoggerConfig struct {
 	HasEndTime       bool
 	HasDuration      bool
 	LogHandleFunc    LogHandlerFunc
+	Skipper          Skipper
 }
 
 var DefaultRequestLoggerConfig = RequestLoggerConfig{
@@ -64,6 +65,7 @@ var DefaultRequestLoggerConfig = RequestLoggerConfig{
 	HasStartTime:     true,
 	HasEndTime:       true,
 	HasDuration:      true,
+	Skipper:          DefaultSkipper,
 }
 
 type RequestLoggerValues struct {
@@ -89,8 +91,16 @@ func RequestLoggerWithConfig(config RequestLoggerConfig) poteto.MiddlewareFunc {
 		headers[i] = http.CanonicalHeaderKey(v)
 	}
 
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if config.Skipper(ctx) {
+				return next(ctx)
+			}
+
 			req := ctx.GetRequest()
 			res := ctx.GetResponse()
 
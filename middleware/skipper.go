@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/poteto-go/poteto"
+	"github.com/poteto-go/poteto/constant"
+)
+
+// middleware is skipped if returns true
+//
+// also used as predicate of middleware.If & middleware.Unless
+type Skipper func(ctx poteto.Context) bool
+
+// never skip
+func DefaultSkipper(ctx poteto.Context) bool {
+	return false
+}
+
+// apply middleware only if pred returns true
+//
+//	p.Register(middleware.If(isAdmin, middleware.CamaraWithConfig(config)))
+func If(pred Skipper, middleware poteto.MiddlewareFunc) poteto.MiddlewareFunc {
+	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
+		applied := middleware(next)
+
+		return func(ctx poteto.Context) error {
+			if pred(ctx) {
+				return applied(ctx)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// apply middleware unless pred returns true
+//
+//	p.Register(middleware.Unless(
+//	  middleware.MatchPaths("/health"),
+//	  middleware.CORSWithConfig(config),
+//	))
+func Unless(pred Skipper, middleware poteto.MiddlewareFunc) poteto.MiddlewareFunc {
+	return If(func(ctx poteto.Context) bool {
+		return !pred(ctx)
+	}, middleware)
+}
+
+// return true if request path matches any of patterns
+// path is same as routed one ex: "/public/../admin" -> "/admin" w/ PathPolicyLenient
+// static segment is matched w/ case folding if Context.IsCaseInsensitive
+//
+// ":param" matches one segment, "*" or "*name" matches the rest
+//
+//	config.Skipper = middleware.MatchPaths("/health", "/users/:id/public", "/static/*")
+func MatchPaths(patterns ...string) Skipper {
+	segmentsList := make([][]string, len(patterns))
+	for i, pattern := range patterns {
+		segmentsList[i] = splitPathSegments(pattern)
+	}
+
+	return func(ctx poteto.Context) bool {
+		path := ctx.GetPath()
+		// not routed ex: poteto.NewContext
+		if path == "" {
+			path = ctx.GetRequest().URL.Path
+		}
+
+		pathSegments := splitPathSegments(path)
+		foldCase := ctx.IsCaseInsensitive()
+		for _, segments := range segmentsList {
+			if matchPathSegments(pathSegments, segments, foldCase) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// "/users/1/" -> ["users", "1"]
+// "/" -> []
+func splitPathSegments(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+func matchPathSegments(path, pattern []string, foldCase bool) bool {
+	for i, segment := range pattern {
+		if strings.HasPrefix(segment, constant.WildcardPrefix) {
+			return true
+		}
+
+		if len(path) <= i {
+			return false
+		}
+
+		if strings.HasPrefix(segment, constant.ParamPrefix) {
+			continue
+		}
+
+		if segment == path[i] || (foldCase && strings.EqualFold(segment, path[i])) {
+			continue
+		}
+		return false
+	}
+	return len(path) == len(pattern)
+}
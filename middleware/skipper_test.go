@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poteto-go/poteto"
+	"github.com/poteto-go/poteto/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func headerMiddleware(next poteto.HandlerFunc) poteto.HandlerFunc {
+	return func(ctx poteto.Context) error {
+		ctx.SetResponseHeader("Applied", "true")
+		return next(ctx)
+	}
+}
+
+func serveWithMiddleware(middleware poteto.MiddlewareFunc, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	ctx := poteto.NewContext(w, req)
+
+	handler := middleware(func(ctx poteto.Context) error {
+		return ctx.NoContent()
+	})
+	handler(ctx)
+	return w
+}
+
+func TestIf(t *testing.T) {
+	tests := []struct {
+		name     string
+		pred     Skipper
+		expected string
+	}{
+		{"apply if pred is true", func(ctx poteto.Context) bool { return true }, "true"},
+		{"skip if pred is false", func(ctx poteto.Context) bool { return false }, ""},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			w := serveWithMiddleware(If(it.pred, headerMiddleware), "/test")
+
+			// Assert
+			assert.Equal(t, it.expected, w.Header().Get("Applied"))
+		})
+	}
+}
+
+func TestUnless(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"skip matched path", "/health", ""},
+		{"apply unmatched path", "/users", "true"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			middleware := Unless(MatchPaths("/health"), headerMiddleware)
+
+			// Act
+			w := serveWithMiddleware(middleware, it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, w.Header().Get("Applied"))
+		})
+	}
+}
+
+func TestMatchPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{"static path", []string{"/health"}, "/health", true},
+		{"trailing slash", []string{"/health"}, "/health/", true},
+		{"root", []string{"/"}, "/", true},
+		{"unmatched static path", []string{"/health"}, "/healthz", false},
+		{"param", []string{"/users/:id/public"}, "/users/1/public", true},
+		{"unmatched param", []string{"/users/:id/public"}, "/users/1", false},
+		{"wildcard", []string{"/static/*"}, "/static/css/main.css", true},
+		{"named wildcard", []string{"/static/*filepath"}, "/static/main.css", true},
+		{"unmatched wildcard", []string{"/static/*"}, "/users", false},
+		{"any of patterns", []string{"/health", "/metrics"}, "/metrics", true},
+		{"no pattern", []string{}, "/health", false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, it.path, nil)
+			ctx := poteto.NewContext(httptest.NewRecorder(), req)
+
+			// Act
+			result := MatchPaths(it.patterns...)(ctx)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestMatchPathsOfRoutedPath(t *testing.T) {
+	deny := func(next poteto.HandlerFunc) poteto.HandlerFunc {
+		return func(ctx poteto.Context) error {
+			ctx.WriteHeader(http.StatusUnauthorized)
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		option   poteto.PotetoOption
+		path     string
+		expected int
+	}{
+		{"dot segment w/ lenient", poteto.PotetoOption{PathPolicy: constant.PathPolicyLenient}, "/public/../admin", http.StatusUnauthorized},
+		{"skip w/ lenient", poteto.PotetoOption{PathPolicy: constant.PathPolicyLenient}, "/public//docs", http.StatusOK},
+		{"case folding", poteto.PotetoOption{CaseInsensitive: true}, "/PUBLIC/docs", http.StatusOK},
+		{"not skipped w/o case folding", poteto.PotetoOption{}, "/PUBLIC/docs", http.StatusUnauthorized},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			p := poteto.NewWithOption(it.option)
+			p.Register(Unless(MatchPaths("/public/*"), deny))
+			p.GET("/admin", func(ctx poteto.Context) error {
+				return ctx.NoContent()
+			})
+			p.GET("/public/docs", func(ctx poteto.Context) error {
+				return ctx.JSON(http.StatusOK, nil)
+			})
+
+			// Act
+			res := p.Play(http.MethodGet, it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, res.Code)
+		})
+	}
+}
+
+func TestSkipperOfConfig(t *testing.T) {
+	skipper := MatchPaths("/health")
+
+	camaraConfig := DefaultCamaraConfig
+	camaraConfig.Skipper = skipper
+
+	corsConfig := DefaultCORSConfig
+	corsConfig.Skipper = skipper
+
+	tests := []struct {
+		name       string
+		middleware poteto.MiddlewareFunc
+		header     string
+	}{
+		{"camara", CamaraWithConfig(camaraConfig), constant.XFrameOption},
+		{"cors", CORSWithConfig(corsConfig), constant.HeaderVary},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			skipped := serveWithMiddleware(it.middleware, "/health")
+			applied := serveWithMiddleware(it.middleware, "/users")
+
+			// Assert
+			assert.Equal(t, "", skipped.Header().Get(it.header))
+			assert.NotEqual(t, "", applied.Header().Get(it.header))
+		})
+	}
+}
+
+func TestJWSWithConfigSkipper(t *testing.T) {
+	// Arrange
+	config := &PotetoJWSConfig{
+		SignKey: []byte("secret"),
+		Skipper: MatchPaths("/health"),
+	}
+	jws := JWSWithConfig(config)
+
+	// Act
+	skipped := serveWithMiddleware(jws, "/health")
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	err := jws(func(ctx poteto.Context) error {
+		return ctx.NoContent()
+	})(poteto.NewContext(w, req))
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, skipped.Code)
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 type TimeoutConfig struct {
 	Limit           time.Duration `yaml:"limit"`
 	TimeoutResponse any
+	Skipper         Skipper `yaml:"-"`
 }
 
 type TimeoutResponseEx struct {
@@ -24,6 +25,7 @@ var DefaultTimeoutResponse = TimeoutResponseEx{
 var DefaultTimeoutConfig = TimeoutConfig{
 	Limit:           time.Second * 10,
 	TimeoutResponse: DefaultTimeoutResponse,
+	Skipper:         DefaultSkipper,
 }
 
 func TimeoutWithConfig(config TimeoutConfig) poteto.MiddlewareFunc {
@@ -35,8 +37,16 @@ func TimeoutWithConfig(config TimeoutConfig) poteto.MiddlewareFunc {
 		config.TimeoutResponse = DefaultTimeoutConfig.TimeoutResponse
 	}
 
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
 	return func(next poteto.HandlerFunc) poteto.HandlerFunc {
 		return func(ctx poteto.Context) error {
+			if config.Skipper(ctx) {
+				return next(ctx)
+			}
+
 			var result error
 
 			done := make(chan struct{})
@@ -1,13 +1,17 @@
 package poteto
 
 import (
+	"regexp"
+	"slices"
 	"strings"
+	"sync/atomic"
 )
 
 /*
  * Middleware Group
  * Trie Tree of path -> middleware
  * If you want to apply into all path, Apply to "" path
+ * ":param" & "*wildcard" segment is matched like route
  * Refer, if once found.
  */
 
@@ -18,9 +22,29 @@ type middlewareLinear struct {
 
 type MiddlewareTree interface {
 	SearchMiddlewares(pattern string) []MiddlewareFunc
+
+	// static segment is matched w/ case folding if foldCase
+	// same as route of PotetoOption.CaseInsensitive
+	searchMiddlewares(pattern string, foldCase bool) []MiddlewareFunc
 	Insert(pattern string, middlewares ...MiddlewareFunc) *middlewareTree
 	Register(middlewares ...MiddlewareFunc)
 
+	// set middlewares of the pattern
+	// empty middlewares removes them
+	Replace(pattern string, middlewares ...MiddlewareFunc)
+
+	// deep copy of middleware tree
+	// used for copy-on-write update of poteto
+	clone() MiddlewareTree
+
+	// incremented on every change of the tree
+	// used to find stale compiled chain of route
+	revision() uint64
+
+	// true if middlewares of the route pattern are same for any matched path
+	// EX: false for "/users/:id" w/ "/users/me" middleware
+	isStaticFor(pattern string) bool
+
 	// DFS route & return linearRouter
 	//
 	// []{
@@ -35,44 +59,112 @@ type middlewareTree struct {
 	children    map[string]MiddlewareTree
 	middlewares []MiddlewareFunc
 	key         string
+	// compiled constraint of param node
+	// EX: ":id<int>"
+	constraint *regexp.Regexp
+	// param children in insertion order
+	paramKeys []string
+	// wildcard child
+	// EX: "*filepath"
+	wildcardKey string
+	// shared by all nodes of the tree
+	changes *atomic.Uint64
 }
 
 func NewMiddlewareTree() MiddlewareTree {
 	return &middlewareTree{
 		children: make(map[string]MiddlewareTree),
+		changes:  new(atomic.Uint64),
 	}
 }
 
 func (mt *middlewareTree) SearchMiddlewares(pattern string) []MiddlewareFunc {
-	currentNode := mt
+	return mt.searchMiddlewares(pattern, false)
+}
+
+func (mt *middlewareTree) searchMiddlewares(pattern string, foldCase bool) []MiddlewareFunc {
 	// faster
-	middlewares := mt.middlewares
-	if pattern == "/" {
+	// cap is limited so that append does not write into tree's slice
+	middlewares := mt.middlewares[:len(mt.middlewares):len(mt.middlewares)]
+	if pattern == "/" || pattern == "" {
 		return middlewares
 	}
 
-	rightPattern := pattern[1:]
-	param := ""
+	found, _ := mt.search(pattern[1:], foldCase)
+	return append(middlewares, found...)
+}
 
-	for {
-		id := strings.Index(rightPattern, "/")
-		if id < 0 {
-			param = rightPattern
-		} else {
-			param = rightPattern[:id]
-			rightPattern = rightPattern[(id + 1):]
+// return middlewares of the deepest matched branch & its depth
+// depth is of the deepest node which has middlewares
+//
+// segment is matched in the same order as route:
+// static -> param (w/ constraint) -> wildcard
+// if branches match same depth, former one is used
+//
+// "/users/42/admin" matches "/users/:id/admin"
+// "/static/css/main.css" matches "/static/*filepath"
+// "/ADMIN" matches "/admin" w/ foldCase
+func (mt *middlewareTree) search(rightPattern string, foldCase bool) ([]MiddlewareFunc, int) {
+	param, nextPattern, isLast := rightPattern, "", true
+	if id := strings.Index(rightPattern, "/"); id >= 0 {
+		param, nextPattern, isLast = rightPattern[:id], rightPattern[(id+1):], false
+	}
+	remaining := strings.Count(rightPattern, "/") + 1
+
+	var best []MiddlewareFunc
+	bestDepth := 0
+	walk := func(child *middlewareTree) {
+		middlewares, depth := child.middlewares, 0
+		if len(child.middlewares) != 0 {
+			depth = 1
+		}
+		if !isLast {
+			if found, foundDepth := child.search(nextPattern, foldCase); foundDepth != 0 {
+				middlewares, depth = slices.Concat(child.middlewares, found), foundDepth+1
+			}
 		}
 
-		if nextNode, ok := currentNode.children[param]; ok {
-			currentNode = nextNode.(*middlewareTree)
-			middlewares = append(middlewares, currentNode.middlewares...)
-		} else {
-			// if found ever
-			// You got Middleware Tree
-			break
+		if depth > bestDepth {
+			best, bestDepth = middlewares, depth
+		}
+	}
+
+	if child, ok := mt.children[param]; ok {
+		walk(child.(*middlewareTree))
+	}
+
+	if foldCase {
+		for key, child := range mt.children {
+			if key == param || hasParamPrefix(key) || hasWildcardPrefix(key) || !strings.EqualFold(key, param) {
+				continue
+			}
+			walk(child.(*middlewareTree))
+		}
+	}
+
+	for _, key := range mt.paramKeys {
+		if bestDepth == remaining {
+			return best, bestDepth
+		}
+
+		// already walked as static
+		if key == param {
+			continue
 		}
+
+		child := mt.children[key].(*middlewareTree)
+		if child.constraint != nil && !child.constraint.MatchString(param) {
+			continue
+		}
+		walk(child)
+	}
+
+	// catch-all consumes all of remaining segments
+	if mt.wildcardKey != "" && bestDepth < remaining {
+		best, bestDepth = mt.children[mt.wildcardKey].(*middlewareTree).middlewares, remaining
 	}
-	return middlewares
+
+	return best[:len(best):len(best)], bestDepth
 }
 
 func (mt *middlewareTree) Insert(pattern string, middlewares ...MiddlewareFunc) *middlewareTree {
@@ -94,11 +186,7 @@ func (mt *middlewareTree) Insert(pattern string, middlewares ...MiddlewareFunc)
 		}
 
 		if _, ok := currentNode.children[param]; !ok {
-			currentNode.children[param] = &middlewareTree{
-				children:    make(map[string]MiddlewareTree),
-				middlewares: []MiddlewareFunc{},
-				key:         param,
-			}
+			currentNode.insertChild(param)
 		}
 		currentNode = currentNode.children[param].(*middlewareTree)
 
@@ -110,8 +198,138 @@ func (mt *middlewareTree) Insert(pattern string, middlewares ...MiddlewareFunc)
 	return currentNode
 }
 
+// param & wildcard child is also indexed for search
+// param w/ invalid constraint is matched only as static segment
+func (mt *middlewareTree) insertChild(param string) {
+	child := &middlewareTree{
+		children:    make(map[string]MiddlewareTree),
+		middlewares: []MiddlewareFunc{},
+		key:         param,
+		changes:     mt.changes,
+	}
+
+	switch {
+	case hasParamPrefix(param):
+		if _, constraint, err := parseParamSegment(param); err == nil {
+			child.constraint = constraint
+			mt.paramKeys = append(mt.paramKeys, param)
+		}
+	case hasWildcardPrefix(param):
+		if mt.wildcardKey == "" {
+			mt.wildcardKey = param
+		}
+	}
+
+	mt.children[param] = child
+}
+
 func (mt *middlewareTree) Register(middlewares ...MiddlewareFunc) {
 	mt.middlewares = append(mt.middlewares, middlewares...)
+	mt.changes.Add(1)
+}
+
+func (mt *middlewareTree) Replace(pattern string, middlewares ...MiddlewareFunc) {
+	node := mt.Insert(pattern)
+	node.middlewares = slices.Clone(middlewares)
+	mt.changes.Add(1)
+}
+
+func (mt *middlewareTree) clone() MiddlewareTree {
+	return mt.cloneWith(new(atomic.Uint64))
+}
+
+func (mt *middlewareTree) cloneWith(changes *atomic.Uint64) *middlewareTree {
+	cloned := &middlewareTree{
+		children:    make(map[string]MiddlewareTree, len(mt.children)),
+		middlewares: slices.Clone(mt.middlewares),
+		key:         mt.key,
+		constraint:  mt.constraint,
+		paramKeys:   slices.Clone(mt.paramKeys),
+		wildcardKey: mt.wildcardKey,
+		changes:     changes,
+	}
+	for key, child := range mt.children {
+		cloned.children[key] = child.(*middlewareTree).cloneWith(changes)
+	}
+	return cloned
+}
+
+func (mt *middlewareTree) revision() uint64 {
+	return mt.changes.Load()
+}
+
+func (mt *middlewareTree) isStaticFor(pattern string) bool {
+	if pattern == "/" || pattern == "" {
+		return true
+	}
+
+	segments := strings.Split(pattern[1:], "/")
+	hasWildcard := hasWildcardPrefix(segments[len(segments)-1])
+	return mt.isStaticForSegments(segments, hasWildcard)
+}
+
+// walk candidates of search by pattern segments
+//
+// value of ":param" segment is unknown,
+// so that static or constrained child makes result depend on it.
+// length of "*wildcard" segment is unknown,
+// so that choice between candidates depends on it.
+func (mt *middlewareTree) isStaticForSegments(segments []string, hasWildcard bool) bool {
+	segment := segments[0]
+	candidates := []*middlewareTree{}
+
+	switch {
+	case hasWildcardPrefix(segment):
+		// wildcard child may consume the rest
+		for key := range mt.children {
+			if key != mt.wildcardKey {
+				return false
+			}
+		}
+		return true
+	case hasParamPrefix(segment):
+		for key, child := range mt.children {
+			if key == mt.wildcardKey {
+				continue
+			}
+
+			node := child.(*middlewareTree)
+			if !slices.Contains(mt.paramKeys, key) || node.constraint != nil {
+				return false
+			}
+			candidates = append(candidates, node)
+		}
+	default:
+		if child, ok := mt.children[segment]; ok {
+			candidates = append(candidates, child.(*middlewareTree))
+		}
+		for _, key := range mt.paramKeys {
+			node := mt.children[key].(*middlewareTree)
+			if key != segment && (node.constraint == nil || node.constraint.MatchString(segment)) {
+				candidates = append(candidates, node)
+			}
+		}
+	}
+
+	if hasWildcard {
+		choices := len(candidates)
+		if mt.wildcardKey != "" {
+			choices++
+		}
+		if choices > 1 {
+			return false
+		}
+	}
+
+	if len(segments) == 1 {
+		return true
+	}
+	for _, candidate := range candidates {
+		if !candidate.isStaticForSegments(segments[1:], hasWildcard) {
+			return false
+		}
+	}
+	return true
 }
 
 func (mt *middlewareTree) DFS() []middlewareLinear {
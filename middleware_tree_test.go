@@ -63,3 +63,150 @@ func TestMiddlewareTree_DFS(t *testing.T) {
 		assert.Equal(t, 3, len(results))
 	})
 }
+
+func TestMiddlewareTree_Replace(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	mg.Insert("/users", sampleMiddleware)
+	mg.Insert("/users/hello", sampleMiddleware2)
+
+	// Act
+	mg.Replace("/users", sampleMiddleware2)
+	replaced := len(mg.SearchMiddlewares("/users"))
+	mg.Replace("/users")
+	removed := len(mg.SearchMiddlewares("/users"))
+
+	// Assert
+	assert.Equal(t, 1, replaced)
+	assert.Equal(t, 0, removed)
+	assert.Equal(t, 1, len(mg.SearchMiddlewares("/users/hello")))
+}
+
+func TestMiddlewareTree_Clone(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	mg.Register(sampleMiddleware)
+	mg.Insert("/users", sampleMiddleware)
+
+	// Act
+	cloned := mg.clone()
+	cloned.Register(sampleMiddleware2)
+	cloned.Insert("/users", sampleMiddleware2)
+	cloned.Insert("/items", sampleMiddleware2)
+
+	// Assert
+	assert.Equal(t, 2, len(mg.SearchMiddlewares("/users")))
+	assert.Equal(t, 1, len(mg.SearchMiddlewares("/items")))
+	assert.Equal(t, 4, len(cloned.SearchMiddlewares("/users")))
+	assert.Equal(t, 3, len(cloned.SearchMiddlewares("/items")))
+}
+
+func TestMiddlewareTree_SearchMiddlewaresWithParam(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	mg.Insert("/users", sampleMiddleware)
+	mg.Insert("/users/:id/admin", sampleMiddleware2)
+	mg.Insert("/users/me", sampleMiddleware2)
+	mg.Insert("/items/:id<int>", sampleMiddleware2)
+	mg.Insert("/static/*filepath", sampleMiddleware2)
+	mg.Insert("/static/css/:name/min", sampleMiddleware, sampleMiddleware)
+
+	tests := []struct {
+		name     string
+		target   string
+		expected int
+	}{
+		{"param segment", "/users/42/admin", 2},
+		{"param segment w/o child", "/users/42", 1},
+		{"param segment of unmatched child", "/users/42/profile", 1},
+		{"static is prior to param", "/users/me", 2},
+		{"param is used if static is shallower", "/users/me/admin", 2},
+		{"satisfied constraint", "/items/1", 1},
+		{"unsatisfied constraint", "/items/abc", 0},
+		{"wildcard", "/static/js/main.js", 1},
+		{"wildcard w/o segment", "/static", 0},
+		{"deeper branch is prior to wildcard", "/static/css/main/min", 2},
+		{"wildcard is prior to shallower branch", "/static/css/main", 1},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			middlewares := mg.SearchMiddlewares(it.target)
+
+			// Assert
+			assert.Equal(t, it.expected, len(middlewares))
+		})
+	}
+}
+
+func TestMiddlewareTree_SearchMiddlewaresNotAliased(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	mg.Register(sampleMiddleware)
+	mg.Insert("/users/:id", sampleMiddleware)
+
+	// Act
+	middlewares := mg.SearchMiddlewares("/users/1")
+	_ = append(middlewares, sampleMiddleware2)
+
+	// Assert
+	assert.Equal(t, 2, len(mg.SearchMiddlewares("/users/1")))
+	assert.Equal(t, 1, len(mg.SearchMiddlewares("/users")))
+}
+
+func TestMiddlewareTree_IsStaticFor(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	mg.Insert("/users", sampleMiddleware)
+	mg.Insert("/users/me", sampleMiddleware)
+	mg.Insert("/items/:id", sampleMiddleware)
+	mg.Insert("/posts/:id<int>", sampleMiddleware)
+	mg.Insert("/static/*filepath", sampleMiddleware)
+	mg.Insert("/files/:name", sampleMiddleware)
+	mg.Insert("/files/*filepath", sampleMiddleware)
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected bool
+	}{
+		{"root", "/", true},
+		{"static", "/users/me", true},
+		{"param w/o static sibling", "/items/:item", true},
+		{"param w/ static sibling", "/users/:id", false},
+		{"param w/ constrained sibling", "/posts/:id", false},
+		{"static matched w/ constraint", "/posts/1", true},
+		{"wildcard w/ only wildcard", "/static/*path", true},
+		{"wildcard w/ param sibling", "/files/*path", false},
+		{"static under wildcard choice", "/files/main.css/*path", false},
+		{"unknown path", "/unknown/:id", true},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := mg.isStaticFor(it.pattern)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestMiddlewareTree_Revision(t *testing.T) {
+	// Arrange
+	mg := NewMiddlewareTree()
+	node := mg.Insert("/users", sampleMiddleware)
+	before := mg.revision()
+
+	// Act
+	node.Register(sampleMiddleware2)
+	afterRegister := mg.revision()
+	cloned := mg.clone()
+	cloned.Insert("/items", sampleMiddleware)
+
+	// Assert
+	assert.Less(t, before, afterRegister)
+	assert.Equal(t, afterRegister, mg.revision())
+}
@@ -0,0 +1,196 @@
+package poteto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/poteto-go/poteto/perror"
+	"github.com/poteto-go/poteto/utils"
+)
+
+// how to resolve route registered on both parent & child api
+type ConflictPolicy int
+
+const (
+	// fail whole merge
+	ConflictError ConflictPolicy = iota
+	// replace route of parent w/ child's one
+	ConflictChildWins
+	// keep route of parent & skip child's one
+	ConflictParentWins
+)
+
+type MountConfig struct {
+	ConflictPolicy ConflictPolicy `yaml:"conflict_policy"`
+}
+
+var DefaultMountConfig = MountConfig{
+	ConflictPolicy: ConflictError,
+}
+
+type MountedRoute struct {
+	Method string
+	// prefixed pattern
+	// EX: "/v1/users/:id"
+	Path string
+}
+
+// result of Poteto.MountApi
+type MountReport struct {
+	// routes of child added
+	Added []MountedRoute
+	// routes of parent replaced by child's one w/ ConflictChildWins
+	Replaced []MountedRoute
+	// routes of child skipped w/ ConflictParentWins
+	Skipped []MountedRoute
+	// conflicted routes failing merge w/ ConflictError
+	Conflicts []MountedRoute
+	// prefixed patterns of child's middlewares
+	// they are applied only to added routes except on AddApi
+	Middlewares []string
+}
+
+// merge routes & middlewares of api under prefix
+// middlewares of api are applied only to routes of api
+// so that routes of parent under prefix are not affected
+// w/ mergeTree, they are merged into middleware tree of p instead ex: AddApi
+//
+// whole merge is validated on copy of route table first,
+// so that nothing is applied if any route fails.
+// errors of all failed routes are joined
+func (p *poteto) mountApi(prefix string, api Poteto, config MountConfig, mergeTree bool) (MountReport, error) {
+	var report MountReport
+	err := p.updateTable(func(table *routeTable) error {
+		report = MountReport{}
+		rtr := table.copyRouter()
+		childTree := api.MiddlewareTree()
+
+		errs := []error{}
+		for _, method := range api.Router().Methods() {
+			// expanded routes of optional param are merged once by pattern
+			merged := map[string]struct{}{}
+			for _, lr := range api.Router().DFS(method) {
+				pattern := lr.path
+				if lr.pattern != "" {
+					pattern = lr.pattern
+				}
+				if _, ok := merged[pattern]; ok {
+					continue
+				}
+				merged[pattern] = struct{}{}
+
+				path, err := joinMountPrefix(prefix, pattern)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("[%s] %s: %w", method, pattern, err))
+					continue
+				}
+
+				mounted := MountedRoute{Method: method, Path: path}
+				if registered := findRegistered(rtr, method, path); registered != "" {
+					switch config.ConflictPolicy {
+					case ConflictChildWins:
+						if err := rtr.Remove(method, registered); err != nil {
+							errs = append(errs, err)
+							continue
+						}
+						report.Replaced = append(report.Replaced, MountedRoute{Method: method, Path: registered})
+					case ConflictParentWins:
+						report.Skipped = append(report.Skipped, mounted)
+						continue
+					default:
+						report.Conflicts = append(report.Conflicts, mounted)
+						errs = append(errs, fmt.Errorf("[%s] %s: %w", method, path, perror.ErrRouteAlreadyUsed))
+						continue
+					}
+				}
+
+				opts := lr.routeOptions()
+				if !mergeTree {
+					// route scoped to keep parent's routes as is
+					// middlewares of tree run before ones of route same as on api
+					opts = append([]RouteOption{withMiddlewares(childTree.SearchMiddlewares(lr.path))}, opts...)
+				}
+				if err := rtr.Handle(method, path, lr.handler, opts...); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				report.Added = append(report.Added, mounted)
+			}
+		}
+
+		if len(errs) != 0 {
+			return errors.Join(errs...)
+		}
+
+		mt := table.middlewareTree
+		if mergeTree {
+			mt = table.cloneMiddlewareTree()
+		}
+		for _, lm := range childTree.DFS() {
+			path, err := joinMountPrefix(prefix, lm.path)
+			if err != nil {
+				return err
+			}
+
+			if mergeTree {
+				mt.Insert(path, lm.handler)
+			}
+			report.Middlewares = append(report.Middlewares, path)
+		}
+		return nil
+	})
+
+	return report, p.routeResult(err)
+}
+
+// "/v1", "/users" -> "/v1/users"
+// "", "/users" -> "/users"
+func joinMountPrefix(prefix, path string) (string, error) {
+	if prefix == "" || prefix == "/" {
+		if path == "" {
+			return "/", nil
+		}
+		return path, nil
+	}
+	return utils.BuildSafeUrl(prefix, path)
+}
+
+// registered pattern of a route sharing any expanded path
+// empty if not found
+func findRegistered(rtr Router, method, path string) string {
+	routes := rtr.GetRoutesByMethod(method)
+	if routes == nil {
+		return ""
+	}
+
+	for _, variant := range expandOptionalParams(path) {
+		found := routes.Find(variant)
+		if found == nil || !found.registered {
+			continue
+		}
+
+		if found.pattern == "" {
+			return variant
+		}
+		return found.pattern
+	}
+	return ""
+}
+
+// options to register the route on another router
+func (lr routeLinear) routeOptions() []RouteOption {
+	opts := []RouteOption{}
+	if lr.name != "" {
+		opts = append(opts, WithName(lr.name))
+	}
+	if len(lr.metadata) != 0 {
+		opts = append(opts, withMetadataMap(lr.metadata))
+	}
+	if len(lr.middlewares) != 0 {
+		opts = append(opts, withMiddlewares(lr.middlewares))
+	}
+	if lr.errorHandler != nil {
+		opts = append(opts, withErrorHandler(lr.errorHandler))
+	}
+	return opts
+}
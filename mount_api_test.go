@@ -0,0 +1,146 @@
+package poteto
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/poteto-go/poteto/perror"
+	"github.com/stretchr/testify/assert"
+)
+
+func getForMountTest(body string) HandlerFunc {
+	return func(ctx Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{"from": body})
+	}
+}
+
+func TestPoteto_MountApi(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/health", getForMountTest("parent"))
+	api := New()
+	api.Register(sampleMiddleware)
+	api.GET("/", getForMountTest("child"))
+	api.GET("/users/:id", getForMountTest("child"), WithName("user"))
+
+	// Act
+	report, err := p.MountApi("/v1", api)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []MountedRoute{
+		{Method: http.MethodGet, Path: "/v1"},
+		{Method: http.MethodGet, Path: "/v1/users/:id"},
+	}, report.Added)
+	assert.Equal(t, []string{"/v1"}, report.Middlewares)
+
+	res := p.Play(http.MethodGet, "/v1/users/1")
+	assert.JSONEq(t, `{"from":"child"}`, res.Body.String())
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+	assert.Equal(t, "", p.Play(http.MethodGet, "/health").Header().Get("Hello"))
+	p.GET("/v1/items", getForMountTest("parent"))
+	assert.Equal(t, "", p.Play(http.MethodGet, "/v1/items").Header().Get("Hello"))
+
+	url, _ := p.URL("user", "id", "1")
+	assert.Equal(t, "/v1/users/1", url)
+}
+
+func TestPoteto_MountApiWithConfig(t *testing.T) {
+	setup := func() (Poteto, Poteto) {
+		p := New()
+		p.GET("/v1/users", getForMountTest("parent"))
+		p.GET("/v1/items", getForMountTest("parent"))
+
+		api := New()
+		api.Register(sampleMiddleware)
+		api.GET("/users", getForMountTest("child"))
+		api.GET("/items", getForMountTest("child"))
+		api.GET("/posts", getForMountTest("child"))
+		return p, api
+	}
+
+	t.Run("error policy fails whole merge", func(t *testing.T) {
+		// Arrange
+		p, api := setup()
+
+		// Act
+		report, err := p.MountApiWithConfig("/v1", api, MountConfig{ConflictPolicy: ConflictError})
+
+		// Assert
+		assert.True(t, errors.Is(err, perror.ErrRouteAlreadyUsed))
+		assert.ElementsMatch(t, []MountedRoute{
+			{Method: http.MethodGet, Path: "/v1/users"},
+			{Method: http.MethodGet, Path: "/v1/items"},
+		}, report.Conflicts)
+		assert.Equal(t, http.StatusNotFound, p.Play(http.MethodGet, "/v1/posts").Code)
+		assert.Equal(t, "", p.Play(http.MethodGet, "/v1/users").Header().Get("Hello"))
+	})
+
+	t.Run("child wins", func(t *testing.T) {
+		// Arrange
+		p, api := setup()
+
+		// Act
+		report, err := p.MountApiWithConfig("/v1", api, MountConfig{ConflictPolicy: ConflictChildWins})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(report.Added))
+		assert.ElementsMatch(t, []MountedRoute{
+			{Method: http.MethodGet, Path: "/v1/users"},
+			{Method: http.MethodGet, Path: "/v1/items"},
+		}, report.Replaced)
+		assert.JSONEq(t, `{"from":"child"}`, p.Play(http.MethodGet, "/v1/users").Body.String())
+	})
+
+	t.Run("parent wins", func(t *testing.T) {
+		// Arrange
+		p, api := setup()
+
+		// Act
+		report, err := p.MountApiWithConfig("/v1", api, MountConfig{ConflictPolicy: ConflictParentWins})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []MountedRoute{{Method: http.MethodGet, Path: "/v1/posts"}}, report.Added)
+		assert.Equal(t, 2, len(report.Skipped))
+		parentRes := p.Play(http.MethodGet, "/v1/users")
+		assert.JSONEq(t, `{"from":"parent"}`, parentRes.Body.String())
+		assert.Equal(t, "", parentRes.Header().Get("Hello"))
+		childRes := p.Play(http.MethodGet, "/v1/posts")
+		assert.JSONEq(t, `{"from":"child"}`, childRes.Body.String())
+		assert.Equal(t, "world", childRes.Header().Get("Hello"))
+	})
+}
+
+func TestPoteto_MountApiOptionalParam(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/v1/reports/:year", getForMountTest("parent"))
+	api := New()
+	api.GET("/reports/:year/:month?", getForMountTest("child"))
+
+	// Act
+	report, err := p.MountApiWithConfig("/v1", api, MountConfig{ConflictPolicy: ConflictChildWins})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []MountedRoute{{Method: http.MethodGet, Path: "/v1/reports/:year"}}, report.Replaced)
+	assert.JSONEq(t, `{"from":"child"}`, p.Play(http.MethodGet, "/v1/reports/2024").Body.String())
+	assert.JSONEq(t, `{"from":"child"}`, p.Play(http.MethodGet, "/v1/reports/2024/1").Body.String())
+}
+
+func TestPoteto_MountApiInvalidPrefix(t *testing.T) {
+	// Arrange
+	p := New()
+	api := New()
+	api.GET("/users", getForMountTest("child"))
+
+	// Act
+	_, err := p.MountApi("/../v1", api)
+
+	// Assert
+	assert.True(t, errors.Is(err, perror.ErrPathTraversalNotAllowed))
+	assert.Equal(t, 0, len(p.Routes()))
+}
@@ -0,0 +1,166 @@
+package poteto
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
+)
+
+// get path param as T
+//
+// supported T:
+//   - string, bool, int*, uint*, float* & named types of them
+//   - time.Duration ex: "1h30m"
+//   - time.Time w/ RFC3339 ex: "2025-06-01T00:00:00Z"
+//   - uuid.UUID
+//   - encoding.TextUnmarshaler
+//
+// return HttpError of 400 if param is missing or invalid
+// return perror.ErrUnsupportedParamType if T is not supported
+//
+//	p.GET("/users/:id", func(ctx poteto.Context) error {
+//		id, err := poteto.PathParamAs[int](ctx, "id")
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	})
+func PathParamAs[T any](ctx Context, key string) (T, error) {
+	value, ok := ctx.PathParam(key)
+	if !ok {
+		var zero T
+		return zero, paramError(constant.ParamTypePath, key, perror.ErrParamNotFound)
+	}
+
+	return parseParamAs[T](constant.ParamTypePath, key, value)
+}
+
+// get query param as T
+// supported T is same as PathParamAs
+// first value is used if repeated ex: "?page=1&page=2" -> 1
+//
+// return HttpError of 400 if param is missing or invalid
+//
+//	since, err := poteto.QueryParamAs[time.Time](ctx, "since")
+func QueryParamAs[T any](ctx Context, key string) (T, error) {
+	value, ok := firstQueryValue(ctx, key)
+	if !ok {
+		var zero T
+		return zero, paramError(constant.ParamTypeQuery, key, perror.ErrParamNotFound)
+	}
+
+	return parseParamAs[T](constant.ParamTypeQuery, key, value)
+}
+
+// get query param as T or def if missing
+// supported T is same as PathParamAs
+//
+// return HttpError of 400 if param is invalid
+//
+//	page, err := poteto.QueryParamOr(ctx, "page", 1)
+func QueryParamOr[T any](ctx Context, key string, def T) (T, error) {
+	value, ok := firstQueryValue(ctx, key)
+	if !ok {
+		return def, nil
+	}
+
+	return parseParamAs[T](constant.ParamTypeQuery, key, value)
+}
+
+// first non empty value
+// empty value is treated as missing same as Context.QueryParam
+func firstQueryValue(ctx Context, key string) (string, bool) {
+	for _, value := range ctx.QueryValues()[key] {
+		if value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func parseParamAs[T any](paramType, key, value string) (T, error) {
+	var parsed T
+	if err := parseParam(&parsed, value); err != nil {
+		var zero T
+		// not error of request
+		if errors.Is(err, perror.ErrUnsupportedParamType) {
+			return zero, err
+		}
+		return zero, paramError(paramType, key, err)
+	}
+	return parsed, nil
+}
+
+// parse value into target
+// target must be pointer
+func parseParam(target any, value string) error {
+	var err error
+	switch t := target.(type) {
+	case *time.Duration:
+		*t, err = time.ParseDuration(value)
+		return err
+	case *time.Time:
+		*t, err = time.Parse(time.RFC3339, value)
+		return err
+	case *uuid.UUID:
+		*t, err = uuid.Parse(value)
+		return err
+	case encoding.TextUnmarshaler:
+		return t.UnmarshalText([]byte(value))
+	}
+
+	// named types ex: type UserId int
+	rv := reflect.ValueOf(target).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: %s", perror.ErrUnsupportedParamType, rv.Type())
+	}
+	return nil
+}
+
+// HttpError of 400 w/ cause
+// EX: {"message": "invalid query param: page"}
+func paramError(paramType, key string, err error) error {
+	message := fmt.Sprintf("invalid %s param: %s", paramType, key)
+	if errors.Is(err, perror.ErrParamNotFound) {
+		message = fmt.Sprintf("missing %s param: %s", paramType, key)
+	}
+
+	httpErr := NewHttpError(http.StatusBadRequest, message)
+	httpErr.SetInternalError(err)
+	return httpErr
+}
@@ -0,0 +1,176 @@
+package poteto
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
+	"github.com/stretchr/testify/assert"
+)
+
+type userIdForTest int
+
+func newParamContextForTest(target string) Context {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	ctx := NewContext(httptest.NewRecorder(), req)
+	ctx.SetQueryParam(req.URL.Query())
+	return ctx
+}
+
+func TestPathParamAs(t *testing.T) {
+	// Arrange
+	ctx := newParamContextForTest("/")
+	ctx.SetParam(constant.ParamTypePath, ParamUnit{":id", "12"})
+	ctx.SetParam(constant.ParamTypePath, ParamUnit{":name", "poteto"})
+
+	t.Run("parse int", func(t *testing.T) {
+		// Act
+		id, err := PathParamAs[int](ctx, "id")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, 12, id)
+	})
+
+	t.Run("parse named type", func(t *testing.T) {
+		// Act
+		id, err := PathParamAs[userIdForTest](ctx, "id")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, userIdForTest(12), id)
+	})
+
+	t.Run("400 if invalid", func(t *testing.T) {
+		// Act
+		_, err := PathParamAs[int](ctx, "name")
+
+		// Assert
+		var httpErr *httpError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, "invalid path param: name", httpErr.Message)
+	})
+
+	t.Run("400 if missing", func(t *testing.T) {
+		// Act
+		_, err := PathParamAs[int](ctx, "unknown")
+
+		// Assert
+		var httpErr *httpError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.ErrorIs(t, err, perror.ErrParamNotFound)
+	})
+}
+
+func TestQueryParamAs(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+	ctx := newParamContextForTest(
+		"/?int=-1&uint=1&float=1.5&bool=true&duration=1h30m&time=2025-06-01T09:00:00Z&uuid=" + id.String() + "&addr=127.0.0.1&name=poteto",
+	)
+
+	t.Run("parse supported types", func(t *testing.T) {
+		// Act
+		intValue, intErr := QueryParamAs[int64](ctx, "int")
+		uintValue, uintErr := QueryParamAs[uint8](ctx, "uint")
+		floatValue, floatErr := QueryParamAs[float64](ctx, "float")
+		boolValue, boolErr := QueryParamAs[bool](ctx, "bool")
+		durationValue, durationErr := QueryParamAs[time.Duration](ctx, "duration")
+		timeValue, timeErr := QueryParamAs[time.Time](ctx, "time")
+		uuidValue, uuidErr := QueryParamAs[uuid.UUID](ctx, "uuid")
+		addrValue, addrErr := QueryParamAs[netip.Addr](ctx, "addr")
+		stringValue, stringErr := QueryParamAs[string](ctx, "name")
+
+		// Assert
+		assert.Nil(t, errors.Join(intErr, uintErr, floatErr, boolErr, durationErr, timeErr, uuidErr, addrErr, stringErr))
+		assert.Equal(t, int64(-1), intValue)
+		assert.Equal(t, uint8(1), uintValue)
+		assert.Equal(t, 1.5, floatValue)
+		assert.True(t, boolValue)
+		assert.Equal(t, 90*time.Minute, durationValue)
+		assert.Equal(t, time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), timeValue)
+		assert.Equal(t, id, uuidValue)
+		assert.Equal(t, netip.MustParseAddr("127.0.0.1"), addrValue)
+		assert.Equal(t, "poteto", stringValue)
+	})
+
+	t.Run("400 if invalid", func(t *testing.T) {
+		tests := []struct {
+			name string
+			act  func() error
+		}{
+			{"int", func() error { _, err := QueryParamAs[int](ctx, "float"); return err }},
+			{"uint overflow", func() error { _, err := QueryParamAs[uint](ctx, "int"); return err }},
+			{"bool", func() error { _, err := QueryParamAs[bool](ctx, "name"); return err }},
+			{"duration", func() error { _, err := QueryParamAs[time.Duration](ctx, "int"); return err }},
+			{"time", func() error { _, err := QueryParamAs[time.Time](ctx, "name"); return err }},
+			{"uuid", func() error { _, err := QueryParamAs[uuid.UUID](ctx, "name"); return err }},
+			{"text unmarshaler", func() error { _, err := QueryParamAs[netip.Addr](ctx, "name"); return err }},
+			{"missing", func() error { _, err := QueryParamAs[int](ctx, "unknown"); return err }},
+		}
+
+		for _, it := range tests {
+			t.Run(it.name, func(t *testing.T) {
+				// Act
+				err := it.act()
+
+				// Assert
+				var httpErr *httpError
+				assert.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			})
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		// Act
+		_, err := QueryParamAs[[]string](ctx, "name")
+
+		// Assert
+		var httpErr *httpError
+		assert.False(t, errors.As(err, &httpErr))
+		assert.ErrorIs(t, err, perror.ErrUnsupportedParamType)
+	})
+}
+
+func TestQueryParamOr(t *testing.T) {
+	// Arrange
+	ctx := newParamContextForTest("/?page=2&page=3&name=poteto&limit=")
+
+	tests := []struct {
+		name     string
+		key      string
+		expected int
+		isErr    bool
+	}{
+		{"parse first if repeated", "page", 2, false},
+		{"default if missing", "offset", 20, false},
+		{"default if empty", "limit", 20, false},
+		{"400 if invalid", "name", 0, true},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result, err := QueryParamOr(ctx, it.key, 20)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+			if it.isErr {
+				var httpErr *httpError
+				assert.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
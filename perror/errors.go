@@ -8,4 +8,17 @@ var (
 	ErrPathTraversalNotAllowed = errors.New("path traversal not allowed")
 	ErrPathLengthExceeded      = errors.New("path length exceeded")
 	ErrUnSupportedHTTPMethod   = errors.New("unsupported http method")
+	ErrWildcardNotLast         = errors.New("wildcard segment must be the last segment")
+	ErrInvalidParamConstraint  = errors.New("invalid path param constraint")
+	ErrRouteNameNotFound       = errors.New("route name not found")
+	ErrRouteNameAlreadyUsed    = errors.New("route name already used")
+	ErrMissingRouteParam       = errors.New("missing route param")
+	ErrUnmatchedRouteParam     = errors.New("route param does not satisfy constraint")
+	ErrRouteAlreadyUsed        = errors.New("route already used")
+	ErrAmbiguousRoute          = errors.New("ambiguous route")
+	ErrRouteNotFound           = errors.New("route not found")
+	ErrInvalidOptionalParam    = errors.New("optional param must be trailing path param")
+	ErrParamNotFound           = errors.New("param not found")
+	ErrUnsupportedParamType    = errors.New("unsupported param type")
+	ErrInvalidBindTarget       = errors.New("bind target must be pointer to struct")
 )
@@ -2,18 +2,25 @@ package poteto
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	stdContext "context"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/fatih/color"
 	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 	"github.com/poteto-go/poteto/utils"
 )
 
@@ -27,6 +34,7 @@ type Poteto interface {
 	RunTLS(addr string, cert, key []byte) error
 	Stop(ctx stdContext.Context) error
 	setupServer() error
+	setRouteMiddlewares(method, path string, middlewares []MiddlewareFunc) error
 	Register(middlewares ...MiddlewareFunc)
 	Combine(pattern string, middlewares ...MiddlewareFunc) *middlewareTree
 	SetLogger(logger any)
@@ -39,20 +47,132 @@ type Poteto interface {
 	// add router & middleware tree from api (Poteto)
 	AddApi(api Poteto) error
 
+	// add routes & middlewares of api under prefix
+	// route registered on both fails whole merge
+	//
+	// report, err := p.MountApi("/v1", api) // "/users" of api -> "/v1/users"
+	MountApi(prefix string, api Poteto) (MountReport, error)
+
+	// add routes & middlewares of api under prefix w/ conflict policy
+	//
+	// report, err := p.MountApiWithConfig("/v1", api, poteto.MountConfig{
+	//   ConflictPolicy: poteto.ConflictChildWins,
+	// })
+	// report.Replaced // routes of p replaced by api's one
+	MountApiWithConfig(prefix string, api Poteto, config MountConfig) (MountReport, error)
+
+	// Host makes router group served only on matched host
+	// ":param" label is captured as path param
+	// request of unmatched host is routed by default router
+	//
+	// p.Host(":tenant.example.com", func(leaf Leaf) {
+	//   leaf.GET("/users", func(ctx Context) error {
+	//     tenant, _ := ctx.PathParam("tenant")
+	//     ...
+	//   })
+	// })
+	Host(pattern string, handler LeafHandler)
+
 	// workflow is a function that is executed when the server starts | end
 	// - constant.StartUpWorkflow: "startUp"
 	//  - This is a workflow that is executed when the server starts
 	RegisterWorkflow(workflowType string, priority uint, workflow WorkflowFunc)
 
-	GET(path string, handler HandlerFunc) error
-	POST(path string, handler HandlerFunc) error
-	PUT(path string, handler HandlerFunc) error
-	PATCH(path string, handler HandlerFunc) error
-	DELETE(path string, handler HandlerFunc) error
-	HEAD(path string, handler HandlerFunc) error
-	OPTIONS(path string, handler HandlerFunc) error
-	TRACE(path string, handler HandlerFunc) error
-	CONNECT(path string, handler HandlerFunc) error
+	GET(path string, handler HandlerFunc, opts ...RouteOption) error
+	POST(path string, handler HandlerFunc, opts ...RouteOption) error
+	PUT(path string, handler HandlerFunc, opts ...RouteOption) error
+	PATCH(path string, handler HandlerFunc, opts ...RouteOption) error
+	DELETE(path string, handler HandlerFunc, opts ...RouteOption) error
+	HEAD(path string, handler HandlerFunc, opts ...RouteOption) error
+	OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) error
+	TRACE(path string, handler HandlerFunc, opts ...RouteOption) error
+	CONNECT(path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// register route of any method
+	// EX: p.Handle("PROPFIND", "/files/*filepath", handler)
+	Handle(method, path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// register route of all standard methods
+	Any(path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// mount net/http handler on prefix & its subpaths
+	// prefix is stripped from request path
+	// routes of all standard methods are registered
+	// not standard method ex: PROPFIND is also dispatched to handler
+	//
+	// p.Mount("/legacy", legacyMux) // "/legacy/users" -> "/users"
+	Mount(prefix string, handler http.Handler) error
+
+	// serve files under root directory on prefix
+	// same as FileSystem(prefix, os.DirFS(root))
+	//
+	// p.Static("/static", "./public") // "/static/css/main.css" -> "./public/css/main.css"
+	Static(prefix, root string) error
+
+	// serve files of fs.FS (ex: embed.FS) on prefix w/ DefaultStaticConfig
+	//
+	// index.html, ETag, Last-Modified, Range & precompressed ".gz" are supported
+	FileSystem(prefix string, fsys fs.FS) error
+
+	// serve files of fs.FS on prefix
+	//
+	// p.FileSystemWithConfig("/", dist, poteto.StaticConfig{
+	//   Index:       "index.html",
+	//   SPAFallback: "index.html",
+	// })
+	FileSystemWithConfig(prefix string, fsys fs.FS, config StaticConfig) error
+
+	// Routes & middlewares can be changed at runtime safely.
+	// Each change is applied to copy of route table & swapped atomically,
+	// so that in-flight request sees consistent table w/o lock.
+
+	// unregister route
+	// return perror.ErrRouteNotFound if not registered
+	RemoveRoute(method, path string) error
+
+	// replace registered route w/ new handler
+	// name, metadata, middlewares & error handler of the route are kept
+	// opts override them ex: poteto.WithName
+	// return perror.ErrRouteNotFound if not registered
+	ReplaceRoute(method, path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// replace middlewares applied to the pattern
+	// middlewares of child pattern are kept
+	ReplaceMiddlewares(pattern string, middlewares ...MiddlewareFunc)
+
+	// remove middlewares applied to the pattern
+	// middlewares of child pattern are kept
+	RemoveMiddlewares(pattern string)
+
+	// swap whole route table w/ api's one atomically
+	//
+	// func reload(p poteto.Poteto) {
+	//   api := poteto.New()
+	//   api.GET("/users", handler)
+	//
+	//   p.SwapRoutes(api)
+	// }
+	SwapRoutes(api Poteto)
+
+	// build url of the route named by poteto.WithName
+	// params are key-value pairs
+	//
+	// func main() {
+	//   p := poteto.New()
+	//
+	//   p.GET("/users/:id", handler, poteto.WithName("user"))
+	//
+	//   url, err := p.URL("user", "id", "1") // -> "/users/1"
+	// }
+	//
+	// route named in Poteto.Host is also resolved w/o host
+	URL(name string, params ...string) (string, error)
+
+	// return all registered routes sorted by path & method
+	// w/ handler name, route name & middlewares to apply
+	//
+	// if PotetoOption.DebugMode, route table is printed on startup
+	Routes() []RouteInfo
 
 	// poteto.Play make ut w/o server
 	// EX:
@@ -97,19 +217,42 @@ type Poteto interface {
 	Chain(middlewares ...MiddlewareFunc) func(HandlerFunc) HandlerFunc
 
 	SetErrorHandler(handler ErrorHandlerFunc)
+
+	// handler called if no route is matched
+	// it runs through middlewares like a normal route
+	//
+	// default: 404 w/o body
+	SetNotFoundHandler(handler HandlerFunc)
+
+	// handler called if path exists only under other methods
+	// Allow header is set before it runs through middlewares
+	//
+	// default: return NewHttpError(http.StatusMethodNotAllowed)
+	SetMethodNotAllowedHandler(handler HandlerFunc)
 }
 
 type poteto struct {
-	router          Router
-	ErrorHandler    ErrorHandlerFunc
-	middlewareTree  MiddlewareTree
-	logger          any
-	cache           sync.Pool
-	option          PotetoOption
-	startupMutex    sync.RWMutex
-	Server          http.Server
-	Listener        net.Listener
-	potetoWorkflows PotetoWorkflows
+	// current route table
+	// updated only by updateTable
+	table        atomic.Pointer[routeTable]
+	tableMutex   sync.Mutex
+	ErrorHandler ErrorHandlerFunc
+	// called if no route is matched
+	notFoundHandler         HandlerFunc
+	methodNotAllowedHandler HandlerFunc
+	logger                  any
+	cache                   sync.Pool
+	option                  PotetoOption
+	startupMutex            sync.RWMutex
+	Server                  http.Server
+	Listener                net.Listener
+	potetoWorkflows         PotetoWorkflows
+	// app serving routes of this host app
+	// nil if p is not host app
+	parent *poteto
+	// true after first request
+	// route table is updated in place before it
+	served atomic.Bool
 }
 
 func Api(basePath string, handler LeafHandler) *poteto {
@@ -126,31 +269,100 @@ func New() Poteto {
 		panic(err)
 	}
 
-	return &poteto{
-		router:          NewRouter(),
-		ErrorHandler:    DefaultErrorHandler,
-		middlewareTree:  NewMiddlewareTree(),
-		option:          DefaultPotetoOption,
-		potetoWorkflows: NewPotetoWorkflows(),
-	}
+	return newPoteto(DefaultPotetoOption)
 }
 
 func NewWithOption(option PotetoOption) Poteto {
-	return &poteto{
-		router:          NewRouter(),
-		ErrorHandler:    DefaultErrorHandler,
-		middlewareTree:  NewMiddlewareTree(),
-		option:          option,
-		potetoWorkflows: NewPotetoWorkflows(),
+	return newPoteto(option)
+}
+
+func newPoteto(option PotetoOption) *poteto {
+	rtr := NewRouter()
+	rtr.setCaseInsensitive(option.CaseInsensitive)
+
+	p := &poteto{
+		ErrorHandler:            DefaultErrorHandler,
+		notFoundHandler:         DefaultNotFoundHandler,
+		methodNotAllowedHandler: DefaultMethodNotAllowedHandler,
+		option:                  option,
+		potetoWorkflows:         NewPotetoWorkflows(),
 	}
+	p.table.Store(newRouteTable(rtr, NewMiddlewareTree()))
+	return p
 }
 
+// router of current route table
+// it must not be mutated directly after serving
 func (p *poteto) Router() *router {
-	return p.router.(*router)
+	return p.table.Load().router.(*router)
 }
 
+// middleware tree of current route table
+// it must not be mutated directly after serving
 func (p *poteto) MiddlewareTree() *middlewareTree {
-	return p.middlewareTree.(*middlewareTree)
+	return p.table.Load().middlewareTree.(*middlewareTree)
+}
+
+// apply update to copy of current table & publish it
+// current table is kept if update returns error
+//
+// router is updated in place until first request
+// so that registration of routes does not copy router every time
+func (p *poteto) updateTable(update func(table *routeTable) error) error {
+	p.tableMutex.Lock()
+	defer p.tableMutex.Unlock()
+
+	next := p.table.Load().clone()
+	next.ownsRouter = !p.isServed()
+	if err := update(next); err != nil {
+		return err
+	}
+
+	p.publishTable(next)
+	return nil
+}
+
+// compile chains of routes & publish table
+// caller must hold tableMutex
+func (p *poteto) publishTable(next *routeTable) {
+	var parentTree MiddlewareTree
+	if p.parent != nil {
+		parentTree = p.parent.table.Load().middlewareTree
+	}
+	p.compileChains(next, parentTree)
+	p.table.Store(next)
+
+	// chains of host app depend on middleware tree of p
+	for _, host := range next.hosts {
+		host.app.adopt(p)
+	}
+}
+
+// recompile chains of host app on update of parent
+func (p *poteto) adopt(parent *poteto) {
+	p.tableMutex.Lock()
+	defer p.tableMutex.Unlock()
+
+	p.parent = parent
+	next := p.table.Load().clone()
+	next.ownsRouter = !p.isServed()
+	p.publishTable(next)
+}
+
+// host app is served by parent
+func (p *poteto) isServed() bool {
+	return p.served.Load() || (p.parent != nil && p.parent.isServed())
+}
+
+// wait for update in place & stop it
+func (p *poteto) markServed() {
+	p.tableMutex.Lock()
+	defer p.tableMutex.Unlock()
+
+	p.served.Store(true)
+	for _, host := range p.table.Load().hosts {
+		host.app.markServed()
+	}
 }
 
 // Cashed context | NewContext
@@ -168,6 +380,10 @@ func (p *poteto) initializeContext(w http.ResponseWriter, r *http.Request) *cont
 }
 
 func (p *poteto) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !p.served.Load() {
+		p.markServed()
+	}
+
 	// get from cache & reset context
 	ctx := p.initializeContext(w, r)
 
@@ -187,37 +403,220 @@ func (p *poteto) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	routes := p.router.GetRoutesByMethod(r.Method)
+	// snapshot of this request
+	table := p.table.Load()
+	hostTable, hostParams := table.matchHost(r.Host)
+	// Context.URL resolves route of the host
+	ctx.router = hostTable.router
 
-	targetRoute, httpParams := routes.Search(r.URL.Path)
-	if targetRoute == nil {
-		ctx.WriteHeader(http.StatusNotFound)
+	path, isCanonical := p.resolvePath(hostTable.router, r.URL.Path)
+	if !isCanonical {
+		p.redirectToCanonical(ctx, path)
 		return
 	}
 
+	// pooled so that search does not allocate params
+	params := acquireRouteParams()
+	targetRoute, httpParams := p.searchRoute(hostTable.router, r.Method, path, *params)
 	handler := targetRoute.GetHandler()
+	fromRoute := handler != nil
+
+	var headWriter *headResponseWriter
 	if handler == nil {
-		ctx.WriteHeader(http.StatusNotFound)
-		return
+		switch {
+		// run GET handler w/o body
+		case r.Method == http.MethodHead && p.option.WithImplicitHead:
+			targetRoute, httpParams = p.searchRoute(hostTable.router, http.MethodGet, path, (*params)[:0])
+			handler = targetRoute.GetHandler()
+			if handler != nil {
+				fromRoute = true
+				headWriter = newHeadResponseWriter(w)
+				ctx.response.Reset(headWriter)
+			}
+		case r.Method == http.MethodOptions && p.option.WithImplicitOptions:
+			handler = p.implicitOptionsHandler(hostTable.router, path)
+		}
+	}
+
+	if handler == nil {
+		handler = p.noRouteHandler(ctx, hostTable.router, path)
 	}
 
 	ctx.SetQueryParam(r.URL.Query())
-	ctx.SetPath(r.URL.Path)
+	ctx.SetPath(path)
+	ctx.route = targetRoute
+	for _, httpParam := range hostParams {
+		ctx.SetParam(constant.ParamTypePath, httpParam)
+	}
 	for _, httpParam := range httpParams {
 		ctx.SetParam(constant.ParamTypePath, httpParam)
 	}
+	releaseRouteParams(params)
+
+	// compiled chain of route | search middleware
+	if chain := p.compiledChain(fromRoute, targetRoute, path, table, hostTable); chain != nil {
+		handler = chain
+	} else {
+		// "/ADMIN" runs middlewares of "/admin" same as route
+		middlewares := table.middlewareTree.searchMiddlewares(path, p.option.CaseInsensitive)
+		if hostTable != table {
+			middlewares = slices.Concat(middlewares, hostTable.middlewareTree.searchMiddlewares(path, p.option.CaseInsensitive))
+		}
+		if fromRoute {
+			middlewares = slices.Concat(middlewares, targetRoute.middlewares)
+		}
+		handler = p.applyMiddleware(middlewares, handler)
+	}
 
-	// Search middleware
-	middlewares := p.middlewareTree.SearchMiddlewares(r.URL.Path)
-	handler = p.applyMiddleware(middlewares, handler)
 	if err := handler(ctx); err != nil {
-		p.ErrorHandler(err, ctx)
+		p.handleError(err, ctx, fromRoute, targetRoute)
+	}
+
+	if headWriter != nil {
+		headWriter.flush()
 	}
 
 	// cached context
 	p.cache.Put(ctx)
 }
 
+// error handler of the route is prior to Poteto.ErrorHandler
+func (p *poteto) handleError(err error, ctx Context, fromRoute bool, targetRoute *route) {
+	if fromRoute && targetRoute.errorHandler != nil {
+		targetRoute.errorHandler(err, ctx)
+		return
+	}
+	p.ErrorHandler(err, ctx)
+}
+
+// nil if handler is not of route or chain is stale
+//
+// chain is compiled by route pattern,
+// so that it is not used if requested path may differ from pattern
+// ex: trailing slash, case folding
+func (p *poteto) compiledChain(fromRoute bool, targetRoute *route, path string, table, hostTable *routeTable) HandlerFunc {
+	if !fromRoute || p.option.CaseInsensitive {
+		return nil
+	}
+
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		return nil
+	}
+
+	if hostTable == table {
+		return targetRoute.chain.handlerFor(table.middlewareTree, nil)
+	}
+	return targetRoute.chain.handlerFor(hostTable.middlewareTree, table.middlewareTree)
+}
+
+// apply PotetoOption.PathPolicy
+//
+// return (path to route, true)
+// or (canonical path to redirect, false)
+func (p *poteto) resolvePath(rtr Router, path string) (string, bool) {
+	switch p.option.PathPolicy {
+	case constant.PathPolicyLenient:
+		return utils.CleanPath(path), true
+	case constant.PathPolicyRedirect:
+		canonical := utils.CleanPath(path)
+		if canonical == path {
+			return path, true
+		}
+
+		// redirect only if canonical path is registered
+		if len(rtr.GetAllowedMethods(canonical)) == 0 {
+			return path, true
+		}
+		return canonical, false
+	default:
+		return path, true
+	}
+}
+
+// GET & HEAD -> 301, others -> 308 to keep method & body
+func (p *poteto) redirectToCanonical(ctx *context, canonical string) {
+	code := http.StatusPermanentRedirect
+	if ctx.request.Method == http.MethodGet || ctx.request.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+
+	location := canonical
+	if rawQuery := ctx.request.URL.RawQuery; rawQuery != "" {
+		location += "?" + rawQuery
+	}
+
+	ctx.SetResponseHeader(constant.HeaderLocation, location)
+	ctx.WriteHeader(code)
+
+	p.cache.Put(ctx)
+}
+
+// return nil if not found
+// captured params are appended to params
+func (p *poteto) searchRoute(rtr Router, method, path string, params []ParamUnit) (*route, []ParamUnit) {
+	if routes := rtr.GetRoutesByMethod(method); routes != nil {
+		targetRoute, httpParams := routes.lookup(path, params)
+		if targetRoute.GetHandler() != nil {
+			return targetRoute, httpParams
+		}
+	}
+
+	// ex: PROPFIND on Poteto.Mount
+	fallback := rtr.fallbackRoutes()
+	if fallback == nil {
+		return nil, params
+	}
+	return fallback.lookup(path, params[:0])
+}
+
+// answer 204 & Allow header
+// return nil if path is not registered
+func (p *poteto) implicitOptionsHandler(rtr Router, path string) HandlerFunc {
+	allowedMethods := p.allowedMethods(rtr, path)
+	if len(allowedMethods) == 0 {
+		return nil
+	}
+
+	return func(ctx Context) error {
+		ctx.SetResponseHeader(constant.HeaderAllow, strings.Join(allowedMethods, ", "))
+		return ctx.NoContent()
+	}
+}
+
+// registered methods & implicit HEAD, OPTIONS
+func (p *poteto) allowedMethods(rtr Router, path string) []string {
+	allowedMethods := rtr.GetAllowedMethods(path)
+	if len(allowedMethods) == 0 {
+		return allowedMethods
+	}
+
+	if p.option.WithImplicitHead &&
+		slices.Contains(allowedMethods, http.MethodGet) &&
+		!slices.Contains(allowedMethods, http.MethodHead) {
+		allowedMethods = append(allowedMethods, http.MethodHead)
+	}
+
+	if p.option.WithImplicitOptions &&
+		!slices.Contains(allowedMethods, http.MethodOptions) {
+		allowedMethods = append(allowedMethods, http.MethodOptions)
+	}
+
+	sort.Strings(allowedMethods)
+	return allowedMethods
+}
+
+// path exists under other methods -> MethodNotAllowedHandler & Allow header
+// otherwise -> NotFoundHandler
+func (p *poteto) noRouteHandler(ctx *context, rtr Router, path string) HandlerFunc {
+	allowedMethods := p.allowedMethods(rtr, path)
+	if len(allowedMethods) == 0 {
+		return p.notFoundHandler
+	}
+
+	ctx.SetResponseHeader(constant.HeaderAllow, strings.Join(allowedMethods, ", "))
+	return p.methodNotAllowedHandler
+}
+
 func (p *poteto) applyMiddleware(middlewares []MiddlewareFunc, handler HandlerFunc) HandlerFunc {
 	for _, middleware := range middlewares {
 		handler = middleware(handler)
@@ -330,6 +729,17 @@ func (p *poteto) setupServer() error {
 	coloredBanner := color.HiGreenString(Banner)
 	utils.PotetoPrint(coloredBanner)
 
+	// Print Route Table
+	if p.option.DebugMode {
+		utils.PotetoPrint(formatRouteTable(p.Routes()) + "\n")
+	}
+
+	// compile chains of routes changed directly
+	// ex: p.Router().GET(...)
+	p.updateTable(func(table *routeTable) error {
+		return nil
+	})
+
 	// setting handler
 	p.Server.Handler = p
 
@@ -383,11 +793,29 @@ func (p *poteto) Stop(ctx stdContext.Context) error {
 }
 
 func (p *poteto) Register(middlewares ...MiddlewareFunc) {
-	p.middlewareTree.Insert("", middlewares...)
+	p.Combine("", middlewares...)
 }
 
+// returned node belongs to current route table
+// later change of the table is not reflected on it
 func (p *poteto) Combine(pattern string, middlewares ...MiddlewareFunc) *middlewareTree {
-	return p.middlewareTree.Insert(pattern, middlewares...)
+	var node *middlewareTree
+	p.updateTable(func(table *routeTable) error {
+		node = table.cloneMiddlewareTree().Insert(pattern, middlewares...)
+		return nil
+	})
+	return node
+}
+
+func (p *poteto) ReplaceMiddlewares(pattern string, middlewares ...MiddlewareFunc) {
+	p.updateTable(func(table *routeTable) error {
+		table.cloneMiddlewareTree().Replace(pattern, middlewares...)
+		return nil
+	})
+}
+
+func (p *poteto) RemoveMiddlewares(pattern string) {
+	p.ReplaceMiddlewares(pattern)
 }
 
 func (p *poteto) SetLogger(logger any) {
@@ -404,73 +832,278 @@ func (p *poteto) Leaf(basePath string, yield LeafHandler) {
 	yield(leaf)
 }
 
-var allHttpMethods = []string{
-	http.MethodGet,
-	http.MethodPost,
-	http.MethodPut,
-	http.MethodPatch,
-	http.MethodDelete,
-	http.MethodHead,
-	http.MethodOptions,
-	http.MethodTrace,
-	http.MethodConnect,
+func (p *poteto) Host(pattern string, yield LeafHandler) {
+	p.hostApp(pattern).Leaf("/", yield)
+}
+
+// find or create app of the host pattern
+// host app has own route table w/ same option
+func (p *poteto) hostApp(pattern string) *poteto {
+	normalized := normalizeHost(pattern)
+
+	var app *poteto
+	p.updateTable(func(table *routeTable) error {
+		for _, host := range table.hosts {
+			if host.pattern == normalized {
+				app = host.app
+				return nil
+			}
+		}
+
+		app = newPoteto(p.option)
+		table.hosts = append(table.hosts, newHostRoute(normalized, app))
+		slices.SortStableFunc(table.hosts, func(a, b *hostRoute) int {
+			switch {
+			case !a.hasParam && b.hasParam:
+				return -1
+			case a.hasParam && !b.hasParam:
+				return 1
+			default:
+				return 0
+			}
+		})
+		return nil
+	})
+	return app
 }
 
+// routes & middlewares of api are added atomically
+// nothing is added if any route fails
 func (p *poteto) AddApi(api Poteto) error {
-	// add router
-	for _, method := range allHttpMethods {
-		linearRouter := api.Router().DFS(method)
+	_, err := p.mountApi("", api, DefaultMountConfig, true)
+	return err
+}
+
+func (p *poteto) MountApi(prefix string, api Poteto) (MountReport, error) {
+	return p.mountApi(prefix, api, DefaultMountConfig, false)
+}
+
+func (p *poteto) MountApiWithConfig(prefix string, api Poteto, config MountConfig) (MountReport, error) {
+	return p.mountApi(prefix, api, config, false)
+}
+
+func (p *poteto) GET(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodGet, path, handler, opts...)
+}
+
+func (p *poteto) POST(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodPost, path, handler, opts...)
+}
+
+func (p *poteto) PATCH(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodPatch, path, handler, opts...)
+}
+
+func (p *poteto) PUT(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodPut, path, handler, opts...)
+}
+
+func (p *poteto) DELETE(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodDelete, path, handler, opts...)
+}
+
+func (p *poteto) HEAD(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodHead, path, handler, opts...)
+}
+
+func (p *poteto) OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodOptions, path, handler, opts...)
+}
+
+func (p *poteto) TRACE(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodTrace, path, handler, opts...)
+}
+
+func (p *poteto) CONNECT(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(http.MethodConnect, path, handler, opts...)
+}
+
+func (p *poteto) Handle(method, path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.addRoute(method, path, handler, opts...)
+}
 
-		for _, lr := range linearRouter {
-			if err := p.router.add(method, lr.path, lr.handler); err != nil {
+// route is added to all methods or none
+func (p *poteto) Any(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.routeResult(p.updateTable(func(table *routeTable) error {
+		return table.copyRouter().Any(path, handler, opts...)
+	}))
+}
+
+func (p *poteto) Mount(prefix string, handler http.Handler) error {
+	prefix = utils.CleanPath(prefix)
+	subPath, err := utils.BuildSafeUrl(prefix, mountWildcard)
+	if err != nil {
+		return p.routeResult(err)
+	}
+
+	mounted := mountHandler(prefix, handler)
+	return p.routeResult(p.updateTable(func(table *routeTable) error {
+		rtr := table.copyRouter()
+		for _, path := range []string{prefix, subPath} {
+			if err := rtr.Any(path, mounted); err != nil {
+				return err
+			}
+
+			// not standard method ex: PROPFIND
+			if err := rtr.addFallback(path, mounted); err != nil {
 				return err
 			}
 		}
-	}
+		return nil
+	}))
+}
+
+func (p *poteto) Static(prefix, root string) error {
+	return p.FileSystem(prefix, os.DirFS(root))
+}
+
+func (p *poteto) FileSystem(prefix string, fsys fs.FS) error {
+	return p.FileSystemWithConfig(prefix, fsys, DefaultStaticConfig)
+}
 
-	// add middleware tree
-	linearMiddleware := api.MiddlewareTree().DFS()
-	for _, lm := range linearMiddleware {
-		p.middlewareTree.Insert(lm.path, lm.handler)
+func (p *poteto) FileSystemWithConfig(prefix string, fsys fs.FS, config StaticConfig) error {
+	prefix = utils.CleanPath(prefix)
+	subPath, err := utils.BuildSafeUrl(prefix, staticWildcard)
+	if err != nil {
+		return p.routeResult(err)
 	}
 
-	return nil
+	handler := newStaticHandler(prefix, fsys, config)
+	return p.routeResult(p.updateTable(func(table *routeTable) error {
+		rtr := table.copyRouter()
+		if err := rtr.GET(prefix, handler); err != nil {
+			return err
+		}
+		return rtr.GET(subPath, handler)
+	}))
 }
 
-func (p *poteto) GET(path string, handler HandlerFunc) error {
-	return p.router.GET(path, handler)
+func (p *poteto) addRoute(method, path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.routeResult(p.updateTable(func(table *routeTable) error {
+		return table.cloneRouter().Handle(method, path, handler, opts...)
+	}))
 }
 
-func (p *poteto) POST(path string, handler HandlerFunc) error {
-	return p.router.POST(path, handler)
+func (p *poteto) RemoveRoute(method, path string) error {
+	return p.updateTable(func(table *routeTable) error {
+		return table.cloneRouter().Remove(method, path)
+	})
 }
 
-func (p *poteto) PATCH(path string, handler HandlerFunc) error {
-	return p.router.PATCH(path, handler)
+func (p *poteto) ReplaceRoute(method, path string, handler HandlerFunc, opts ...RouteOption) error {
+	return p.routeResult(p.updateTable(func(table *routeTable) error {
+		rtr := table.copyRouter()
+		kept := registeredOptions(rtr, method, path)
+		if err := rtr.Remove(method, path); err != nil {
+			return err
+		}
+		return rtr.Handle(method, path, handler, append(kept, opts...)...)
+	}))
 }
 
-func (p *poteto) PUT(path string, handler HandlerFunc) error {
-	return p.router.PUT(path, handler)
+// replace middlewares applied only to the route
+// ex: middlewares of Leaf.Register
+// return perror.ErrRouteNotFound if not registered
+func (p *poteto) setRouteMiddlewares(method, path string, middlewares []MiddlewareFunc) error {
+	return p.updateTable(func(table *routeTable) error {
+		routes := table.cloneRouter().GetRoutesByMethod(method)
+		if routes == nil {
+			return fmt.Errorf("[%s] %s: %w", method, path, perror.ErrRouteNotFound)
+		}
+
+		if path != "/" {
+			path = strings.TrimSuffix(path, "/")
+		}
+
+		variants := expandOptionalParams(path)
+		for _, variant := range variants {
+			if found := routes.Find(variant); found == nil || !found.registered {
+				return fmt.Errorf("[%s] %s: %w", method, path, perror.ErrRouteNotFound)
+			}
+		}
+
+		for _, variant := range variants {
+			found := routes.Find(variant)
+			found.middlewares = middlewares
+			// recompiled on publish
+			found.chain = nil
+		}
+		return nil
+	})
 }
 
-func (p *poteto) DELETE(path string, handler HandlerFunc) error {
-	return p.router.DELETE(path, handler)
+// options of registered route
+// nil if not registered
+func registeredOptions(rtr Router, method, path string) []RouteOption {
+	routes := rtr.GetRoutesByMethod(method)
+	if routes == nil {
+		return nil
+	}
+
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	found := routes.Find(expandOptionalParams(path)[0])
+	if found == nil || !found.registered {
+		return nil
+	}
+
+	return routeLinear{
+		name:         found.name,
+		metadata:     found.metadata,
+		middlewares:  found.middlewares,
+		errorHandler: found.errorHandler,
+	}.routeOptions()
 }
 
-func (p *poteto) HEAD(path string, handler HandlerFunc) error {
-	return p.router.HEAD(path, handler)
+func (p *poteto) SwapRoutes(api Poteto) {
+	next := newRouteTable(api.Router().clone(), api.MiddlewareTree().clone())
+	if apiPoteto, ok := api.(*poteto); ok {
+		next.hosts = slices.Clone(apiPoteto.table.Load().hosts)
+	}
+
+	p.tableMutex.Lock()
+	defer p.tableMutex.Unlock()
+	p.publishTable(next)
 }
 
-func (p *poteto) OPTIONS(path string, handler HandlerFunc) error {
-	return p.router.OPTIONS(path, handler)
+// panic on registration error if StrictRouting
+func (p *poteto) routeResult(err error) error {
+	if err != nil && p.option.StrictRouting {
+		panic(err)
+	}
+	return err
 }
 
-func (p *poteto) TRACE(path string, handler HandlerFunc) error {
-	return p.router.TRACE(path, handler)
+func (p *poteto) URL(name string, params ...string) (string, error) {
+	table := p.table.Load()
+	url, err := table.router.URL(name, params...)
+	if !errors.Is(err, perror.ErrRouteNameNotFound) {
+		return url, err
+	}
+
+	// route named in Poteto.Host
+	// static host first, then host w/ param
+	for _, host := range table.hosts {
+		if hostUrl, hostErr := host.app.URL(name, params...); !errors.Is(hostErr, perror.ErrRouteNameNotFound) {
+			return hostUrl, hostErr
+		}
+	}
+	return url, err
 }
 
-func (p *poteto) CONNECT(path string, handler HandlerFunc) error {
-	return p.router.CONNECT(path, handler)
+func (p *poteto) Routes() []RouteInfo {
+	table := p.table.Load()
+
+	routeInfos := table.routeInfos("", nil)
+	for _, host := range table.hosts {
+		hostTable := host.app.table.Load()
+		routeInfos = append(routeInfos, hostTable.routeInfos(host.pattern, table.middlewareTree)...)
+	}
+
+	sortRouteInfos(routeInfos)
+	return routeInfos
 }
 
 func (p *poteto) Play(method, path string, body ...string) *httptest.ResponseRecorder {
@@ -497,7 +1130,12 @@ func (p *poteto) Play(method, path string, body ...string) *httptest.ResponseRec
 }
 
 func (p *poteto) Check(method, path string) bool {
-	routes := p.router.GetRoutesByMethod(method)
+	routes := p.table.Load().router.GetRoutesByMethod(method)
+	if routes == nil {
+		// ex: PROPFIND not registered
+		return false
+	}
+
 	targetRoute, _ := routes.Search(path)
 	if targetRoute == nil {
 		return false
@@ -518,3 +1156,11 @@ func (p *poteto) Chain(middlewares ...MiddlewareFunc) func(HandlerFunc) HandlerF
 func (p *poteto) SetErrorHandler(handler ErrorHandlerFunc) {
 	p.ErrorHandler = handler
 }
+
+func (p *poteto) SetNotFoundHandler(handler HandlerFunc) {
+	p.notFoundHandler = handler
+}
+
+func (p *poteto) SetMethodNotAllowedHandler(handler HandlerFunc) {
+	p.methodNotAllowedHandler = handler
+}
@@ -4,9 +4,35 @@ package poteto
 //   WITH_REQUEST_ID: bool [true]
 //   DEBUG_MODE: bool [false]
 //   LISTENER_NETWORK: string [tcp]
+//   WITH_IMPLICIT_HEAD: bool [true]
+//   WITH_IMPLICIT_OPTIONS: bool [true]
+//   PATH_POLICY: string [strict]
+//   CASE_INSENSITIVE: bool [false]
+//   STRICT_ROUTING: bool [false]
 type PotetoOption struct {
 	WithRequestId      bool   `yaml:"with_request_id" env:"WITH_REQUEST_ID" envDefault:"true"`
 	DebugMode          bool   `yaml:"debug_mode" env:"DEBUG_MODE" envDefault:"false"`
 	ListenerNetwork    string `yaml:"listener_network" env:"LISTENER_NETWORK" envDefault:"tcp"`
 	MaxQueryParamCount int    `yaml:"max_query_param_count" env:"MAX_QUERY_PARAM_COUNT" envDefault:"32"`
+
+	// HEAD is answered by GET handler w/o body
+	WithImplicitHead bool `yaml:"with_implicit_head" env:"WITH_IMPLICIT_HEAD" envDefault:"true"`
+
+	// OPTIONS is answered by 204 & Allow header
+	// if no OPTIONS handler is registered
+	WithImplicitOptions bool `yaml:"with_implicit_options" env:"WITH_IMPLICIT_OPTIONS" envDefault:"true"`
+
+	// how to handle non canonical request path
+	// canonical path has no trailing slash, duplicate slash & dot-segment
+	//   "strict": no cleaning (constant.PathPolicyStrict)
+	//   "redirect": redirect to canonical path (constant.PathPolicyRedirect)
+	//   "lenient": route canonical path (constant.PathPolicyLenient)
+	PathPolicy string `yaml:"path_policy" env:"PATH_POLICY" envDefault:"strict"`
+
+	// "/Users" matches "/users"
+	CaseInsensitive bool `yaml:"case_insensitive" env:"CASE_INSENSITIVE" envDefault:"false"`
+
+	// panic on duplicate or ambiguous route registration
+	// instead of returning error
+	StrictRouting bool `yaml:"strict_routing" env:"STRICT_ROUTING" envDefault:"false"`
 }
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"bou.ke/monkey"
 	"github.com/goccy/go-json"
 	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -286,6 +288,7 @@ func TestCheck(t *testing.T) {
 		{"hit handler", http.MethodGet, "/users", true},
 		{"different path", http.MethodGet, "/unexpected", false},
 		{"different method", http.MethodPost, "/users", false},
+		{"unregistered method", "PROPFIND", "/users", false},
 	}
 
 	for _, it := range tests {
@@ -593,3 +596,1012 @@ func TestPoteto_SetErrorHandler(t *testing.T) {
 	// Assert
 	assert.Equal(t, 200, w.Result().StatusCode)
 }
+
+func TestPoteto_ServeHTTPWildcard(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/static/*filepath", func(ctx Context) error {
+		filepath, _ := ctx.PathParam("filepath")
+		return ctx.JSON(http.StatusOK, map[string]string{
+			"filepath": filepath,
+		})
+	})
+
+	// Act
+	res := p.Play(http.MethodGet, "/static/css/main.css")
+	result := map[string]string{}
+	json.Unmarshal(res.Body.Bytes(), &result)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "css/main.css", result["filepath"])
+}
+
+func TestPoteto_AddApiWildcard(t *testing.T) {
+	// Arrange
+	p := New()
+	api := Api("/assets", func(assetApi Leaf) {
+		assetApi.GET("/*filepath", func(ctx Context) error {
+			return ctx.NoContent()
+		})
+	})
+
+	// Act
+	err := p.AddApi(api)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, p.Check(http.MethodGet, "/assets/js/app.js"))
+	assert.Equal(t, "/assets/*filepath", p.Router().DFS(http.MethodGet)[0].path)
+}
+
+func TestPoteto_ServeHTTPConstraint(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users/:id<int>", func(ctx Context) error {
+		id, _ := ctx.PathParam("id")
+		return ctx.JSON(http.StatusOK, map[string]string{
+			"id": id,
+		})
+	})
+
+	// Act
+	resMatched := p.Play(http.MethodGet, "/users/1")
+	resUnmatched := p.Play(http.MethodGet, "/users/poteto")
+	result := map[string]string{}
+	json.Unmarshal(resMatched.Body.Bytes(), &result)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resMatched.Code)
+	assert.Equal(t, "1", result["id"])
+	assert.Equal(t, http.StatusNotFound, resUnmatched.Code)
+}
+
+func TestPoteto_ServeHTTPMethodNotAllowed(t *testing.T) {
+	t.Run("405 with allow header", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+		p.POST("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodDelete, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, POST", res.Header().Get(constant.HeaderAllow))
+	})
+
+	t.Run("404 if path does not exist under any method", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodDelete, "/unexpected")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, "", res.Header().Get(constant.HeaderAllow))
+	})
+
+	t.Run("405 on unknown method", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play("PROPFIND", "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS", res.Header().Get(constant.HeaderAllow))
+	})
+
+	t.Run("can override by error handler", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+		p.SetErrorHandler(func(err error, ctx Context) {
+			httpErr, _ := err.(*httpError)
+			ctx.JSON(httpErr.Code, map[string]any{
+				"status": httpErr.Code,
+				"error":  "custom",
+			})
+		})
+
+		// Act
+		res := p.Play(http.MethodPost, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS", res.Header().Get(constant.HeaderAllow))
+		assert.Equal(t, `{"error":"custom","status":405}`+"\n", res.Body.String())
+	})
+}
+
+func TestPoteto_ServeHTTPImplicitHead(t *testing.T) {
+	t.Run("answer HEAD by GET handler w/o body", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodHead, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "", res.Body.String())
+		assert.Equal(t, constant.ApplicationJson, res.Header().Get(constant.HeaderContentType))
+		assert.Equal(t, "16", res.Header().Get(constant.HeaderContentLength))
+	})
+
+	t.Run("explicit HEAD handler has priority", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+		p.HEAD("/users", func(ctx Context) error {
+			return ctx.NoContent()
+		})
+
+		// Act
+		res := p.Play(http.MethodHead, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("disabled by option", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{WithImplicitHead: false})
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodHead, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, http.MethodGet, res.Header().Get(constant.HeaderAllow))
+	})
+}
+
+func TestPoteto_ServeHTTPImplicitOptions(t *testing.T) {
+	t.Run("answer allowed methods", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+		p.POST("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodOptions, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, POST", res.Header().Get(constant.HeaderAllow))
+	})
+
+	t.Run("run through middleware", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Register(sampleMiddleware)
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodOptions, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Equal(t, "world", res.Header().Get("Hello"))
+	})
+
+	t.Run("404 if path is not registered", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodOptions, "/unexpected")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("disabled by option", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{WithImplicitOptions: false})
+		p.GET("/users", getAllUserForTest)
+
+		// Act
+		res := p.Play(http.MethodOptions, "/users")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, http.MethodGet, res.Header().Get(constant.HeaderAllow))
+	})
+}
+
+func TestPoteto_ServeHTTPPathPolicy(t *testing.T) {
+	getUserPath := func(ctx Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{
+			"path": ctx.GetPath(),
+		})
+	}
+
+	tests := []struct {
+		name             string
+		option           PotetoOption
+		method           string
+		url              string
+		expectedCode     int
+		expectedLocation string
+	}{
+		{"strict: trailing slash", PotetoOption{PathPolicy: constant.PathPolicyStrict}, http.MethodGet, "/users/", http.StatusNotFound, ""},
+		{"strict: duplicate slash", PotetoOption{PathPolicy: constant.PathPolicyStrict}, http.MethodGet, "//users", http.StatusNotFound, ""},
+		{"strict: canonical", PotetoOption{PathPolicy: constant.PathPolicyStrict}, http.MethodGet, "/users", http.StatusOK, ""},
+		{"redirect: trailing slash", PotetoOption{PathPolicy: constant.PathPolicyRedirect}, http.MethodGet, "/users/", http.StatusMovedPermanently, "/users"},
+		{"redirect: keep query", PotetoOption{PathPolicy: constant.PathPolicyRedirect}, http.MethodGet, "//users?page=1", http.StatusMovedPermanently, "/users?page=1"},
+		{"redirect: dot segment", PotetoOption{PathPolicy: constant.PathPolicyRedirect}, http.MethodGet, "/admin/../users", http.StatusMovedPermanently, "/users"},
+		{"redirect: 308 for post", PotetoOption{PathPolicy: constant.PathPolicyRedirect}, http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users"},
+		{"redirect: not registered", PotetoOption{PathPolicy: constant.PathPolicyRedirect}, http.MethodGet, "/unexpected/", http.StatusNotFound, ""},
+		{"lenient: trailing slash", PotetoOption{PathPolicy: constant.PathPolicyLenient}, http.MethodGet, "/users/", http.StatusOK, ""},
+		{"lenient: duplicate slash", PotetoOption{PathPolicy: constant.PathPolicyLenient}, http.MethodGet, "//users", http.StatusOK, ""},
+		{"case sensitive", PotetoOption{}, http.MethodGet, "/Users", http.StatusNotFound, ""},
+		{"case insensitive", PotetoOption{CaseInsensitive: true}, http.MethodGet, "/Users", http.StatusOK, ""},
+		{"case insensitive & lenient", PotetoOption{CaseInsensitive: true, PathPolicy: constant.PathPolicyLenient}, http.MethodGet, "/USERS/", http.StatusOK, ""},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			p := NewWithOption(it.option)
+			p.GET("/users", getUserPath)
+			p.POST("/users", getUserPath)
+
+			// Act
+			res := p.Play(it.method, it.url)
+
+			// Assert
+			assert.Equal(t, it.expectedCode, res.Code)
+			assert.Equal(t, it.expectedLocation, res.Header().Get(constant.HeaderLocation))
+		})
+	}
+
+	t.Run("lenient: route path is canonical", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{PathPolicy: constant.PathPolicyLenient})
+		p.GET("/users", getUserPath)
+
+		// Act
+		res := p.Play(http.MethodGet, "/users/")
+		result := map[string]string{}
+		json.Unmarshal(res.Body.Bytes(), &result)
+
+		// Assert
+		assert.Equal(t, "/users", result["path"])
+	})
+}
+
+func TestPoteto_CaseInsensitiveMiddleware(t *testing.T) {
+	// Arrange
+	deny := func(next HandlerFunc) HandlerFunc {
+		return func(ctx Context) error {
+			ctx.WriteHeader(http.StatusUnauthorized)
+			return nil
+		}
+	}
+	p := NewWithOption(PotetoOption{CaseInsensitive: true})
+	p.Combine("/admin", deny)
+	p.GET("/admin", getAllUserForTest)
+	p.GET("/admin/:id", getAllUserForTest)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"same case", "/admin"},
+		{"upper case", "/ADMIN"},
+		{"mixed case", "/Admin"},
+		{"sub path", "/AdMiN/1"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(http.MethodGet, it.path)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, res.Code)
+		})
+	}
+}
+
+func TestPoteto_URL(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Leaf("/users", func(leaf Leaf) {
+		leaf.GET("/:id", func(ctx Context) error {
+			url, err := ctx.URL("user-posts", "id", "1", "postId", "2")
+			if err != nil {
+				return err
+			}
+			return ctx.JSON(http.StatusOK, map[string]string{"url": url})
+		}, WithName("user"))
+		leaf.GET("/:id/posts/:postId", getAllUserForTest, WithName("user-posts"))
+	})
+
+	t.Run("p.URL", func(t *testing.T) {
+		// Act
+		url, err := p.URL("user", "id", "poteto")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "/users/poteto", url)
+	})
+
+	t.Run("p.URL missing param", func(t *testing.T) {
+		// Act
+		_, err := p.URL("user")
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrMissingRouteParam)
+	})
+
+	t.Run("ctx.URL", func(t *testing.T) {
+		// Act
+		res := p.Play(http.MethodGet, "/users/1")
+		result := map[string]string{}
+		json.Unmarshal(res.Body.Bytes(), &result)
+
+		// Assert
+		assert.Equal(t, "/users/1/posts/2", result["url"])
+	})
+
+	t.Run("AddApi keeps name", func(t *testing.T) {
+		// Arrange
+		parent := New()
+
+		// Act
+		err := parent.AddApi(p)
+		url, errURL := parent.URL("user", "id", "1")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, errURL)
+		assert.Equal(t, "/users/1", url)
+	})
+}
+
+func TestPoteto_Routes(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(sampleMiddleware)
+	p.GET("/", getAllUserForTest)
+	p.Leaf("/users", func(leaf Leaf) {
+		leaf.Register(sampleMiddleware2)
+		leaf.POST("/", getAllUserForTest)
+		leaf.GET("/:id", getAllUserForTestById, WithName("user"))
+	})
+
+	// Act
+	result := p.Routes()
+
+	// Assert
+	assert.Equal(t, []RouteInfo{
+		{
+			Method:      http.MethodGet,
+			Path:        "/",
+			Handler:     "github.com/poteto-go/poteto.getAllUserForTest",
+			Middlewares: []string{"github.com/poteto-go/poteto.sampleMiddleware"},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/users",
+			Handler: "github.com/poteto-go/poteto.getAllUserForTest",
+			Middlewares: []string{
+				"github.com/poteto-go/poteto.sampleMiddleware2",
+				"github.com/poteto-go/poteto.sampleMiddleware",
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/users/:id",
+			Handler: "github.com/poteto-go/poteto.getAllUserForTestById",
+			Name:    "user",
+			Middlewares: []string{
+				"github.com/poteto-go/poteto.sampleMiddleware2",
+				"github.com/poteto-go/poteto.sampleMiddleware",
+			},
+		},
+	}, result)
+}
+
+func orderMiddlewareForTest(next HandlerFunc) HandlerFunc {
+	return func(ctx Context) error {
+		ctx.GetResponse().Header().Add("Order", "orderMiddlewareForTest")
+		return next(ctx)
+	}
+}
+
+func orderMiddleware2ForTest(next HandlerFunc) HandlerFunc {
+	return func(ctx Context) error {
+		ctx.GetResponse().Header().Add("Order", "orderMiddleware2ForTest")
+		return next(ctx)
+	}
+}
+
+func TestPoteto_RoutesMiddlewaresInOrderOfExecution(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(orderMiddlewareForTest)
+	p.Combine("/users", orderMiddleware2ForTest)
+	p.GET("/users", getAllUserForTest)
+
+	// Act
+	result := p.Routes()
+	res := p.Play(http.MethodGet, "/users")
+
+	// Assert
+	executed := []string{}
+	for _, name := range res.Header().Values("Order") {
+		executed = append(executed, "github.com/poteto-go/poteto."+name)
+	}
+	assert.Equal(t, executed, result[0].Middlewares)
+}
+
+func TestPoteto_AddApiRoot(t *testing.T) {
+	// Arrange
+	p := New()
+	api := New()
+	api.GET("/", getAllUserForTest)
+
+	// Act
+	err := p.AddApi(api)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, p.Check(http.MethodGet, "/"))
+}
+
+func TestPoteto_HandleCustomMethod(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Handle("PROPFIND", "/files/*filepath", func(ctx Context) error {
+		filepath, _ := ctx.PathParam("filepath")
+		return ctx.JSON(http.StatusMultiStatus, map[string]string{
+			"filepath": filepath,
+		})
+	})
+	p.GET("/files/*filepath", getAllUserForTest)
+
+	t.Run("serve custom method", func(t *testing.T) {
+		// Act
+		res := p.Play("PROPFIND", "/files/docs/a.txt")
+		result := map[string]string{}
+		json.Unmarshal(res.Body.Bytes(), &result)
+
+		// Assert
+		assert.Equal(t, http.StatusMultiStatus, res.Code)
+		assert.Equal(t, "docs/a.txt", result["filepath"])
+	})
+
+	t.Run("custom method in allow header", func(t *testing.T) {
+		// Act
+		res := p.Play(http.MethodDelete, "/files/docs/a.txt")
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, PROPFIND", res.Header().Get(constant.HeaderAllow))
+	})
+
+	t.Run("AddApi keeps custom method", func(t *testing.T) {
+		// Arrange
+		parent := New()
+
+		// Act
+		err := parent.AddApi(p)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.True(t, parent.Check("PROPFIND", "/files/a.txt"))
+	})
+}
+
+func TestPoteto_Any(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Leaf("/users", func(leaf Leaf) {
+		leaf.Any("/", getAllUserForTest)
+		leaf.Handle("PURGE", "/cache", getAllUserForTest)
+	})
+
+	// Act & Assert
+	for _, method := range allHttpMethods {
+		assert.True(t, p.Check(method, "/users"), method)
+	}
+	assert.True(t, p.Check("PURGE", "/users/cache"))
+}
+
+func TestPoteto_StrictRouting(t *testing.T) {
+	t.Run("return error if not strict", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{})
+		p.GET("/users/:id", getAllUserForTest)
+
+		// Act
+		err := p.GET("/users/:name", getAllUserForTest)
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrAmbiguousRoute)
+	})
+
+	t.Run("panic on ambiguous route if strict", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{StrictRouting: true})
+		p.GET("/users/:id", getAllUserForTest)
+
+		// Act & Assert
+		assert.Panics(t, func() {
+			p.Leaf("/users", func(leaf Leaf) {
+				leaf.GET("/:name", getAllUserForTest)
+			})
+		})
+	})
+
+	t.Run("panic on duplicate route of AddApi if strict", func(t *testing.T) {
+		// Arrange
+		p := NewWithOption(PotetoOption{StrictRouting: true})
+		p.GET("/users", getAllUserForTest)
+		api := New()
+		api.GET("/users", getAllUserForTest)
+
+		// Act & Assert
+		assert.Panics(t, func() {
+			p.AddApi(api)
+		})
+	})
+}
+
+func TestPoteto_Host(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(sampleMiddleware)
+	p.GET("/users", func(ctx Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{"host": "default"})
+	})
+	p.Host("admin.example.com", func(leaf Leaf) {
+		leaf.Register(sampleMiddleware2)
+		leaf.GET("/users", func(ctx Context) error {
+			return ctx.JSON(http.StatusOK, map[string]string{"host": "admin"})
+		})
+	})
+	p.Host(":tenant.example.com", func(leaf Leaf) {
+		leaf.GET("/users/:id", func(ctx Context) error {
+			tenant, _ := ctx.PathParam("tenant")
+			id, _ := ctx.PathParam("id")
+			return ctx.JSON(http.StatusOK, map[string]string{"host": tenant, "id": id})
+		})
+	})
+
+	tests := []struct {
+		name         string
+		host         string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{"static host", "admin.example.com", "/users", http.StatusOK, `{"host":"admin"}`},
+		{"static host w/ port", "Admin.example.com:8080", "/users", http.StatusOK, `{"host":"admin"}`},
+		{"param host", "acme.example.com", "/users/1", http.StatusOK, `{"host":"acme","id":"1"}`},
+		{"not found on matched host", "acme.example.com", "/users", http.StatusNotFound, ""},
+		{"fall back to default router", "example.com", "/users", http.StatusOK, `{"host":"default"}`},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, it.path, nil)
+			req.Host = it.host
+
+			// Act
+			p.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, it.expectedCode, w.Code)
+			if it.expectedBody != "" {
+				assert.JSONEq(t, it.expectedBody, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("middleware of default & host", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Host = "admin.example.com"
+
+		// Act
+		p.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, "world", w.Header().Get("Hello"))
+		assert.Equal(t, "world2", w.Header().Get("Hello2"))
+	})
+
+	t.Run("routes include host", func(t *testing.T) {
+		// Act
+		routes := p.Routes()
+
+		// Assert
+		assert.Equal(t, 3, len(routes))
+		assert.Equal(t, "", routes[0].Host)
+		assert.Equal(t, ":tenant.example.com", routes[1].Host)
+		assert.Equal(t, "admin.example.com", routes[2].Host)
+	})
+}
+
+func TestPoteto_HostNamedRoute(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users/:id", getAllUserForTest, WithName("user"))
+	p.Host(":tenant.example.com", func(leaf Leaf) {
+		leaf.GET("/items/:id", func(ctx Context) error {
+			url, err := ctx.URL("tenantItem", "id", "2")
+			if err != nil {
+				return err
+			}
+			return ctx.JSON(http.StatusOK, map[string]string{"url": url})
+		}, WithName("tenantItem"))
+	})
+
+	t.Run("Poteto.URL resolves route of host", func(t *testing.T) {
+		// Act
+		url, err := p.URL("tenantItem", "id", "1")
+		defaultUrl, defaultErr := p.URL("user", "id", "1")
+		_, notFoundErr := p.URL("unknown")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "/items/1", url)
+		assert.Nil(t, defaultErr)
+		assert.Equal(t, "/users/1", defaultUrl)
+		assert.ErrorIs(t, notFoundErr, perror.ErrRouteNameNotFound)
+	})
+
+	t.Run("Context.URL resolves route of host", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Host = "acme.example.com"
+
+		// Act
+		p.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"url":"/items/2"}`, w.Body.String())
+	})
+}
+
+func TestPoteto_RouteMetadata(t *testing.T) {
+	// Arrange
+	p := New()
+	var matched MatchedRoute
+	p.Register(func(next HandlerFunc) HandlerFunc {
+		return func(ctx Context) error {
+			matched = ctx.Route()
+			return next(ctx)
+		}
+	})
+	p.Leaf("/admin", func(leaf Leaf) {
+		leaf.SetMetadata("scope", "admin")
+		leaf.SetMetadata("owner", "platform")
+		leaf.GET("/users/:id", getAllUserForTest, WithName("adminUser"), WithMetadata("owner", "identity"))
+	})
+
+	// Act
+	p.Play(http.MethodGet, "/admin/users/1")
+
+	// Assert
+	assert.Equal(t, MatchedRoute{
+		Method:   http.MethodGet,
+		Pattern:  "/admin/users/:id",
+		Name:     "adminUser",
+		Metadata: map[string]any{"scope": "admin", "owner": "identity"},
+	}, matched)
+
+	t.Run("kept by AddApi", func(t *testing.T) {
+		// Arrange
+		parent := New()
+
+		// Act
+		parent.AddApi(p)
+
+		// Assert
+		routes := parent.Routes()
+		assert.Equal(t, 1, len(routes))
+		assert.Equal(t, map[string]any{"scope": "admin", "owner": "identity"}, routes[0].Metadata)
+	})
+}
+
+func TestPoteto_RemoveRoute(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users", getAllUserForTest)
+	p.POST("/users", getAllUserForTest)
+
+	// Act
+	err := p.RemoveRoute(http.MethodGet, "/users")
+	errNotFound := p.RemoveRoute(http.MethodGet, "/users")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, errNotFound, perror.ErrRouteNotFound)
+	assert.False(t, p.Check(http.MethodGet, "/users"))
+	assert.True(t, p.Check(http.MethodPost, "/users"))
+	assert.Equal(t, http.StatusMethodNotAllowed, p.Play(http.MethodGet, "/users").Code)
+}
+
+func TestPoteto_ReplaceRoute(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users", getAllUserForTest, WithName("users"))
+
+	// Act
+	err := p.ReplaceRoute(http.MethodGet, "/users", func(ctx Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{"name": "replaced"})
+	}, WithName("users"))
+	errNotFound := p.ReplaceRoute(http.MethodGet, "/items", getAllUserForTest)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, errNotFound, perror.ErrRouteNotFound)
+	assert.JSONEq(t, `{"name":"replaced"}`, p.Play(http.MethodGet, "/users").Body.String())
+	url, _ := p.URL("users")
+	assert.Equal(t, "/users", url)
+}
+
+func TestPoteto_ReplaceRouteKeepsOptions(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Leaf("/users", func(leaf Leaf) {
+		leaf.Register(sampleMiddleware)
+		leaf.SetMetadata("scope", "admin")
+		leaf.GET("/", getAllUserForTest, WithName("users"))
+	})
+
+	// Act
+	err := p.ReplaceRoute(http.MethodGet, "/users", func(ctx Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{"name": "replaced"})
+	}, WithMetadata("version", "v2"))
+
+	// Assert
+	assert.Nil(t, err)
+	res := p.Play(http.MethodGet, "/users")
+	assert.JSONEq(t, `{"name":"replaced"}`, res.Body.String())
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+	url, err := p.URL("users")
+	assert.Nil(t, err)
+	assert.Equal(t, "/users", url)
+	assert.Equal(t, map[string]any{"scope": "admin", "version": "v2"}, p.Routes()[0].Metadata)
+}
+
+func TestPoteto_ReplaceMiddlewares(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(sampleMiddleware)
+	p.Combine("/users", sampleMiddleware2)
+	p.GET("/users", getAllUserForTest)
+
+	// Act
+	p.ReplaceMiddlewares("/users", sampleMiddleware)
+	p.RemoveMiddlewares("/")
+	res := p.Play(http.MethodGet, "/users")
+
+	// Assert
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+	assert.Equal(t, "", res.Header().Get("Hello2"))
+	assert.Equal(t, 1, len(p.MiddlewareTree().SearchMiddlewares("/users")))
+}
+
+func TestPoteto_SwapRoutes(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users", getAllUserForTest)
+	api := New()
+	api.Register(sampleMiddleware)
+	api.GET("/items", getAllUserForTest)
+
+	// Act
+	p.SwapRoutes(api)
+	api.GET("/later", getAllUserForTest)
+
+	// Assert
+	assert.False(t, p.Check(http.MethodGet, "/users"))
+	assert.True(t, p.Check(http.MethodGet, "/items"))
+	assert.False(t, p.Check(http.MethodGet, "/later"))
+	assert.Equal(t, "world", p.Play(http.MethodGet, "/items").Header().Get("Hello"))
+}
+
+func TestPoteto_AddApiAtomic(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users/:id", getAllUserForTest)
+	api := New()
+	api.GET("/items", getAllUserForTest)
+	api.GET("/users/:id", getAllUserForTest)
+
+	// Act
+	err := p.AddApi(api)
+
+	// Assert
+	assert.ErrorIs(t, err, perror.ErrRouteAlreadyUsed)
+	assert.False(t, p.Check(http.MethodGet, "/items"))
+}
+
+func TestPoteto_UpdateRoutesWhileServing(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/users", getAllUserForTest)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				// Act
+				res := p.Play(http.MethodGet, "/users")
+
+				// Assert
+				assert.Equal(t, http.StatusOK, res.Code)
+			}
+		}()
+	}
+
+	for j := 0; j < 100; j++ {
+		p.GET("/items", getAllUserForTest)
+		p.Combine("/items", sampleMiddleware)
+		p.RemoveRoute(http.MethodGet, "/items")
+	}
+	wg.Wait()
+}
+
+func TestPoteto_RouterIsCopiedOnlyAfterServing(t *testing.T) {
+	t.Run("updated in place before serving", func(t *testing.T) {
+		// Arrange
+		p := New().(*poteto)
+		p.GET("/users", getAllUserForTest)
+		rtr := p.Router()
+
+		// Act
+		p.GET("/items", getAllUserForTest)
+		p.Combine("/items", sampleMiddleware)
+
+		// Assert
+		assert.Same(t, rtr, p.Router())
+		assert.True(t, p.Check(http.MethodGet, "/items"))
+	})
+
+	t.Run("copied on update after serving", func(t *testing.T) {
+		// Arrange
+		p := New().(*poteto)
+		p.GET("/users", getAllUserForTest)
+		p.Play(http.MethodGet, "/users")
+		rtr := p.Router()
+
+		// Act
+		p.GET("/items", getAllUserForTest)
+
+		// Assert
+		assert.NotSame(t, rtr, p.Router())
+		old, _ := rtr.GetRoutesByMethod(http.MethodGet).Search("/items")
+		assert.Nil(t, old)
+		assert.True(t, p.Check(http.MethodGet, "/items"))
+	})
+}
+
+func TestPoteto_OptionalParam(t *testing.T) {
+	// Arrange
+	p := New()
+	p.GET("/reports/:year/:month?", func(ctx Context) error {
+		year, _ := ctx.PathParam("year")
+		month, ok := ctx.PathParam("month")
+		if !ok {
+			month = "all"
+		}
+		return ctx.JSON(http.StatusOK, map[string]string{"year": year, "month": month})
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedBody string
+	}{
+		{"w/o optional param", "/reports/2025", `{"year":"2025","month":"all"}`},
+		{"w/ optional param", "/reports/2025/06", `{"year":"2025","month":"06"}`},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := p.Play(http.MethodGet, it.path)
+
+			// Assert
+			assert.True(t, p.Check(http.MethodGet, it.path))
+			assert.JSONEq(t, it.expectedBody, res.Body.String())
+		})
+	}
+
+	t.Run("AddApi keeps optional param", func(t *testing.T) {
+		// Arrange
+		parent := New()
+
+		// Act
+		err := parent.AddApi(p)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(parent.Routes()))
+		assert.Equal(t, "/reports/:year/:month?", parent.Router().GetRoutesByMethod(http.MethodGet).Find("/reports/:year").pattern)
+	})
+}
+
+func TestPoteto_SetNotFoundHandler(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(sampleMiddleware)
+	p.Combine("/users", sampleMiddleware2)
+	p.GET("/users", getAllUserForTest)
+	p.SetNotFoundHandler(func(ctx Context) error {
+		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found: " + ctx.GetPath()})
+	})
+
+	// Act
+	res := p.Play(http.MethodGet, "/users/1/unknown")
+	resDefault := New().Play(http.MethodGet, "/unknown")
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, res.Code)
+	assert.JSONEq(t, `{"error":"not found: /users/1/unknown"}`, res.Body.String())
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+	assert.Equal(t, "world2", res.Header().Get("Hello2"))
+	assert.Equal(t, http.StatusNotFound, resDefault.Code)
+	assert.Equal(t, "", resDefault.Body.String())
+}
+
+func TestPoteto_SetMethodNotAllowedHandler(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Register(sampleMiddleware)
+	p.POST("/users", getAllUserForTest)
+	p.SetMethodNotAllowedHandler(func(ctx Context) error {
+		return ctx.JSON(http.StatusMethodNotAllowed, map[string]string{
+			"error": "allowed: " + ctx.GetResponse().Header().Get(constant.HeaderAllow),
+		})
+	})
+
+	// Act
+	res := p.Play(http.MethodPut, "/users")
+
+	// Assert
+	assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+	assert.JSONEq(t, `{"error":"allowed: OPTIONS, POST"}`, res.Body.String())
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+}
+
+func TestPoteto_CombineWithParam(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Combine("/users/:id/admin", sampleMiddleware)
+	p.GET("/users/:id/admin", getAllUserForTest)
+	p.GET("/users/:id", getAllUserForTest)
+
+	// Act
+	res := p.Play(http.MethodGet, "/users/42/admin")
+	resOther := p.Play(http.MethodGet, "/users/42")
+
+	// Assert
+	assert.Equal(t, "world", res.Header().Get("Hello"))
+	assert.Equal(t, "", resOther.Header().Get("Hello"))
+}
@@ -2,7 +2,9 @@ package poteto
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/poteto-go/poteto/constant"
 	"github.com/poteto-go/poteto/utils"
 )
 
@@ -124,3 +126,48 @@ func (r *response) Reset(w http.ResponseWriter) {
 	r.Size = 0
 	r.IsCommitted = false
 }
+
+// http.ResponseWriter for implicit HEAD
+//
+// discard body but keep headers & Content-Length
+// status is written on flush
+type headResponseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func newHeadResponseWriter(w http.ResponseWriter) *headResponseWriter {
+	return &headResponseWriter{ResponseWriter: w}
+}
+
+func (w *headResponseWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.size += len(b)
+	return len(b), nil
+}
+
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *headResponseWriter) flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if w.size > 0 && w.Header().Get(constant.HeaderContentLength) == "" {
+		w.Header().Set(constant.HeaderContentLength, strconv.Itoa(w.size))
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+}
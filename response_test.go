@@ -5,6 +5,9 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWriteHeader(t *testing.T) {
@@ -115,3 +118,52 @@ func TestResponseController(t *testing.T) {
 	rc.SetWriteDeadline(time.Now().Add(5 * time.Second))
 	res.Write([]byte("done"))
 }
+
+func TestHeadResponseWriter(t *testing.T) {
+	t.Run("discard body & set content-length", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		hw := newHeadResponseWriter(w)
+
+		// Act
+		hw.WriteHeader(http.StatusCreated)
+		hw.Write([]byte("hello"))
+		hw.Write([]byte("world"))
+		hw.flush()
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "10", w.Header().Get(constant.HeaderContentLength))
+		assert.Equal(t, "", w.Body.String())
+	})
+
+	t.Run("keep content-length set by handler", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		hw := newHeadResponseWriter(w)
+
+		// Act
+		hw.Header().Set(constant.HeaderContentLength, "100")
+		hw.Write([]byte("hello"))
+		hw.flush()
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "100", w.Header().Get(constant.HeaderContentLength))
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		hw := newHeadResponseWriter(w)
+
+		// Act
+		hw.WriteHeader(http.StatusNoContent)
+		hw.flush()
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "", w.Header().Get(constant.HeaderContentLength))
+		assert.Equal(t, w, hw.Unwrap())
+	})
+}
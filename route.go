@@ -2,137 +2,705 @@ package poteto
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 	"github.com/poteto-go/poteto/utils"
 )
 
 type routeLinear struct {
 	path    string
 	handler HandlerFunc
+	// registered pattern
+	// "/reports/:year/:month?" for both "/reports/:year" & "/reports/:year/:month"
+	pattern      string
+	name         string
+	metadata     map[string]any
+	middlewares  []MiddlewareFunc
+	errorHandler ErrorHandlerFunc
 }
 
 type Route interface {
 	Search(path string) (*route, []ParamUnit)
+
+	// optional trailing param is expanded
+	// "/reports/:year/:month?" -> "/reports/:year" & "/reports/:year/:month"
 	Insert(path string, handler HandlerFunc)
 
+	// Find route by registered pattern
+	// it does not resolve any param or wildcard
+	//
+	// "/users/:id" -> finds only the node of "/users/:id"
+	Find(path string) *route
+
+	// return perror.ErrAmbiguousRoute if pattern is ambiguous w/ registered one
+	//
+	// "/users/:id" & "/users/:name/posts" -> different param names
+	// "/files/*path" & "/files/*filepath" -> different wildcard names
+	// "/files/:name" & "/files/*filepath" -> param overlaps wildcard
+	FindConflict(path string) error
+
+	// unregister route by registered pattern
+	// & prune nodes which no longer lead to any route
+	// optional trailing param is expanded
+	// return false if not registered
+	Remove(path string) bool
+
 	// DFS route & return linearRouter
 	//
 	// []{
 	//   path: string,
 	//   handler: HandlerFunc,
+	//   pattern: string,
+	//   name: string,
+	//   metadata: map[string]any,
+	//   middlewares: []MiddlewareFunc,
+	//   errorHandler: ErrorHandlerFunc,
 	// }
+	//
+	// optional param is reported as expanded routes
 	DFS() []routeLinear
 	dfs(node *route, path string, visited *map[string]struct{}, results *[]routeLinear)
 
 	GetHandler() HandlerFunc
 }
 
+// node of compressed radix tree
+//
+// static part of path is shared by byte
+// param & wildcard take whole segment
+//
+//	"/users/find", "/users/:id", "/users/:id/posts"
+//
+//	"/"
+//	└ "users/"
+//	  ├ "find"
+//	  └ ":id"
+//	    └ "/posts"
 type route struct {
-	children      map[string]Route
-	childParamKey string
-	handler       HandlerFunc
+	// static: part of path ex: "users/"
+	// param & wildcard: registered segment ex: ":id<int>", "*filepath"
+	prefix string
+	// first byte of each static child
+	indices  string
+	children []*route
+	// constrained params come first
+	// ex: [":id<int>", ":name<alpha>", ":key"]
+	paramChildren []*route
+	wildcardChild *route
+	// key of ParamUnit captured by this node ex: ":id"
+	paramKey   string
+	constraint *regexp.Regexp
+	handler    HandlerFunc
+	// registered pattern ex: "/users/:id"
+	pattern string
+	// expanded path of this node
+	// EX: "/reports/:year" of "/reports/:year/:month?"
+	path string
+	// handler w/ middlewares
+	// compiled on update of route table
+	chain *routeChain
+	// named by poteto.WithName
+	name string
+	// attached by poteto.WithMetadata
+	metadata map[string]any
+	// applied only to this route ex: Leaf.Register
+	middlewares []MiddlewareFunc
+	// used instead of Poteto.ErrorHandler ex: Leaf.SetErrorHandler
+	errorHandler ErrorHandlerFunc
+	// true if route is registered by Insert
+	// (intermediate node is false)
+	registered bool
+	// only used on root
+	// static segment is matched w/ case folding
+	caseInsensitive bool
+	// only used on root
+	// registered route w/o any param & wildcard
+	// EX: "/users/find"
+	static map[string]*route
 }
 
 func NewRoute() Route {
 	return &route{
-		children:      make(map[string]Route),
-		childParamKey: "",
+		prefix:   "/",
+		children: []*route{},
+		static:   map[string]*route{},
 	}
 }
 
+// fixed capacity of pooled param buffer
+// route w/ more params allocates on search
+const routeParamsCapacity = 16
+
+var routeParamsPool = sync.Pool{
+	New: func() any {
+		params := make([]ParamUnit, 0, routeParamsCapacity)
+		return &params
+	},
+}
+
+func acquireRouteParams() *[]ParamUnit {
+	return routeParamsPool.Get().(*[]ParamUnit)
+}
+
+// params must not be used after release
+func releaseRouteParams(params *[]ParamUnit) {
+	*params = (*params)[:0]
+	routeParamsPool.Put(params)
+}
+
+// Search route by requested path
+//
+// Priority of children is static > :param > *wildcard.
+// If the preferred child does not lead to any handler,
+// search falls back to the next candidate.
 func (r *route) Search(path string) (*route, []ParamUnit) {
-	currentRoute := r
-	rightPath := path[1:]
-	param := ""
-	httpParams := make([]ParamUnit, 0)
+	params := acquireRouteParams()
+	defer releaseRouteParams(params)
 
-	if rightPath == "" {
-		return currentRoute, httpParams
+	found, httpParams := r.lookup(path, *params)
+	if len(httpParams) == 0 {
+		return found, nil
 	}
+	return found, slices.Clone(httpParams)
+}
 
-	// optimized router insert
-	// https://github.com/poteto-go/poteto/issues/113
-	for {
-		id := strings.Index(rightPath, "/")
-		if id < 0 {
-			param = rightPath
-		} else {
-			param = rightPath[:id]
-			rightPath = rightPath[(id + 1):]
-		}
-
-		if nextRoute, ok := currentRoute.children[param]; ok {
-			currentRoute = nextRoute.(*route)
-		} else {
-			// includes url param ex: /users/:id, /users/:id/name
-			if chParam := currentRoute.childParamKey; chParam != "" {
-				if nextRoute, ok = currentRoute.children[chParam]; ok {
-					currentRoute = nextRoute.(*route)
-					httpParam := ParamUnit{key: chParam, value: param}
-					httpParams = append(httpParams, httpParam)
+// Search w/ buffer of params
+// returned params share buffer
+func (r *route) lookup(path string, params []ParamUnit) (*route, []ParamUnit) {
+	// exact match of route w/o any param & wildcard
+	if node, ok := r.static[path]; ok && node.handler != nil {
+		return node, params
+	}
+
+	if path == r.prefix {
+		return r, params
+	}
+
+	if !strings.HasPrefix(path, r.prefix) {
+		return nil, params
+	}
+	return r.search(path[len(r.prefix):], params, r.caseInsensitive)
+}
+
+// rightPath is requested path after prefix of r
+func (r *route) search(rightPath string, params []ParamUnit, foldCase bool) (*route, []ParamUnit) {
+	if rightPath == "" && r.handler != nil {
+		return r, params
+	}
+
+	var fallback *route
+
+	if child := r.matchChild(rightPath, foldCase); child != nil {
+		found, foundParams := child.search(rightPath[len(child.prefix):], params, foldCase)
+		if found != nil {
+			if found.handler != nil {
+				return found, foundParams
+			}
+			fallback = found
+		}
+	}
+
+	// param & wildcard start at segment ex: "/users/" of "/users/:id"
+	if strings.HasSuffix(r.prefix, "/") {
+		param, nextPath := rightPath, ""
+		if id := strings.IndexByte(rightPath, '/'); id >= 0 {
+			param, nextPath = rightPath[:id], rightPath[id:]
+		}
+
+		// includes url param ex: /users/:id, /users/:id/name
+		// param not satisfying constraint falls through to sibling
+		for _, paramRoute := range r.paramChildren {
+			if paramRoute.constraint != nil && !paramRoute.constraint.MatchString(param) {
+				continue
+			}
+
+			httpParam := ParamUnit{key: paramRoute.paramKey, value: param}
+			found, foundParams := paramRoute.search(nextPath, append(params, httpParam), foldCase)
+			if found != nil {
+				if found.handler != nil {
+					return found, foundParams
+				}
+				if fallback == nil {
+					fallback = found
 				}
-			} else {
-				return nil, httpParams
 			}
 		}
 
-		if id < 0 {
-			break
+		// catch-all: ex: /static/*filepath
+		if wildcardRoute := r.wildcardChild; wildcardRoute != nil {
+			if wildcardRoute.handler != nil || fallback == nil {
+				httpParam := ParamUnit{key: wildcardRoute.paramKey, value: rightPath}
+				return wildcardRoute, append(params, httpParam)
+			}
+		}
+	}
+
+	// intermediate node has no captured param
+	if fallback != nil {
+		return fallback, params
+	}
+	if rightPath == "" {
+		return r, params
+	}
+	return nil, params
+}
+
+// static child whose prefix leads rightPath
+func (r *route) matchChild(rightPath string, foldCase bool) *route {
+	if rightPath == "" {
+		return nil
+	}
+
+	if id := strings.IndexByte(r.indices, rightPath[0]); id >= 0 {
+		if child := r.children[id]; strings.HasPrefix(rightPath, child.prefix) {
+			return child
 		}
 	}
 
-	return currentRoute, httpParams
+	if !foldCase {
+		return nil
+	}
+
+	// "Users" -> "users"
+	for _, child := range r.children {
+		if len(rightPath) >= len(child.prefix) && strings.EqualFold(rightPath[:len(child.prefix)], child.prefix) {
+			return child
+		}
+	}
+	return nil
 }
 
 func (r *route) Insert(path string, handler HandlerFunc) {
+	for _, variant := range expandOptionalParams(path) {
+		r.insert(variant, handler)
+	}
+}
+
+func (r *route) insert(path string, handler HandlerFunc) {
 	currentRoute := r
-	rightPath := path[1:]
-	param := ""
-
-	// optimized router insert
-	// https://github.com/poteto-go/poteto/issues/113
-	for {
-		id := strings.Index(rightPath, "/")
-		if id < 0 { // means last
-			param = rightPath
-		} else {
-			param = rightPath[:id]
-			rightPath = rightPath[(id + 1):]
-		}
-
-		if nextRoute := currentRoute.children[param]; nextRoute == nil {
-			// last path includes url param ex: /users/:id
-			if hasParamPrefix(param) {
-				currentRoute.childParamKey = param
-			}
+	rightPath := path[len(r.prefix):]
+	isStatic := true
 
-			currentRoute.children[param] = &route{
-				children: make(map[string]Route),
-			}
+	// "/" is registered on root itself
+	for rightPath != "" {
+		// url param ex: /users/:id, /users/:id<int>
+		// catch-all ex: /static/*filepath
+		if hasParamPrefix(rightPath) || hasWildcardPrefix(rightPath) {
+			param := segmentOf(rightPath)
+			currentRoute = currentRoute.insertParamChild(param)
+			rightPath = rightPath[len(param):]
+			isStatic = false
+			continue
 		}
-		currentRoute = currentRoute.children[param].(*route)
 
-		if id < 0 {
-			break
+		// static part lasts until param or wildcard segment
+		static := rightPath
+		if id := indexOfDynamicSegment(rightPath); id >= 0 {
+			static = rightPath[:id]
 		}
+
+		currentRoute = currentRoute.insertStaticChild(static)
+		rightPath = rightPath[len(static):]
 	}
 
-	if currentRoute.handler != nil {
+	if currentRoute.registered {
 		coloredWarn := color.HiRedString(fmt.Sprintf("Handler Collision on %s \n", path))
 		utils.PotetoPrint(coloredWarn)
 		return
 	}
 
 	currentRoute.handler = handler
+	currentRoute.registered = true
+	currentRoute.chain = nil
+	if isStatic {
+		r.static[path] = currentRoute
+	}
+}
+
+// index of "/:" or "/*" + 1
+// -1 if path has no param & wildcard
+func indexOfDynamicSegment(path string) int {
+	for i := 0; i < len(path)-1; i++ {
+		if path[i] != '/' {
+			continue
+		}
+
+		if next := path[i+1:]; hasParamPrefix(next) || hasWildcardPrefix(next) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// split static children so that node ends w/ static
+// "users/find" + "users/" -> "users/" -> "find"
+func (r *route) insertStaticChild(static string) *route {
+	currentRoute := r
+	for static != "" {
+		id := strings.IndexByte(currentRoute.indices, static[0])
+		if id < 0 {
+			child := &route{
+				prefix:   static,
+				children: []*route{},
+			}
+			currentRoute.addChild(child)
+			return child
+		}
+
+		child := currentRoute.children[id]
+		common := commonPrefixLength(child.prefix, static)
+		if common < len(child.prefix) {
+			// registered node keeps its pointer
+			parent := &route{
+				prefix:   child.prefix[:common],
+				indices:  child.prefix[common : common+1],
+				children: []*route{child},
+			}
+			child.prefix = child.prefix[common:]
+			currentRoute.children[id] = parent
+			child = parent
+		}
+
+		currentRoute = child
+		static = static[common:]
+	}
+	return currentRoute
+}
+
+func (r *route) insertParamChild(param string) *route {
+	if hasWildcardPrefix(param) {
+		if r.wildcardChild == nil {
+			r.wildcardChild = &route{
+				prefix:   param,
+				children: []*route{},
+				paramKey: wildcardParamKey(param),
+			}
+		}
+		return r.wildcardChild
+	}
+
+	for _, child := range r.paramChildren {
+		if child.prefix == param {
+			return child
+		}
+	}
+
+	// constraint is validated on router.add
+	paramKey, constraint, _ := parseParamSegment(param)
+	child := &route{
+		prefix:     param,
+		children:   []*route{},
+		paramKey:   paramKey,
+		constraint: constraint,
+	}
+	r.addParamChild(child)
+	return child
+}
+
+func (r *route) addChild(child *route) {
+	r.indices += child.prefix[:1]
+	r.children = append(r.children, child)
+}
+
+// constrained param is preferred to unconstrained one
+func (r *route) addParamChild(child *route) {
+	if child.constraint == nil {
+		r.paramChildren = append(r.paramChildren, child)
+		return
+	}
+
+	id := slices.IndexFunc(r.paramChildren, func(paramRoute *route) bool {
+		return paramRoute.constraint == nil
+	})
+	if id < 0 {
+		r.paramChildren = append(r.paramChildren, child)
+		return
+	}
+	r.paramChildren = slices.Insert(r.paramChildren, id, child)
+}
+
+func commonPrefixLength(a, b string) int {
+	length := min(len(a), len(b))
+	for i := 0; i < length; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return length
+}
+
+// ":id/posts" -> ":id"
+func segmentOf(path string) string {
+	if id := strings.IndexByte(path, '/'); id >= 0 {
+		return path[:id]
+	}
+	return path
+}
+
+func (r *route) Find(path string) *route {
+	nodes := r.walkPattern(path)
+	if nodes == nil {
+		return nil
+	}
+	return nodes[len(nodes)-1]
+}
+
+// nodes from root to the node of registered pattern
+// nil if pattern does not end w/ any node
+func (r *route) walkPattern(path string) []*route {
+	if !strings.HasPrefix(path, r.prefix) {
+		return nil
+	}
+
+	nodes := []*route{r}
+	currentRoute := r
+	rightPath := path[len(r.prefix):]
+	for rightPath != "" {
+		nextRoute := currentRoute.childOfPattern(rightPath)
+		if nextRoute == nil {
+			return nil
+		}
+
+		rightPath = rightPath[len(nextRoute.prefix):]
+		currentRoute = nextRoute
+		nodes = append(nodes, currentRoute)
+	}
+	return nodes
+}
+
+// child whose prefix leads rightPath of registered pattern
+func (r *route) childOfPattern(rightPath string) *route {
+	switch {
+	case hasParamPrefix(rightPath):
+		param := segmentOf(rightPath)
+		for _, child := range r.paramChildren {
+			if child.prefix == param {
+				return child
+			}
+		}
+		return nil
+	case hasWildcardPrefix(rightPath):
+		if child := r.wildcardChild; child != nil && child.prefix == segmentOf(rightPath) {
+			return child
+		}
+		return nil
+	}
+
+	if id := strings.IndexByte(r.indices, rightPath[0]); id >= 0 {
+		if child := r.children[id]; strings.HasPrefix(rightPath, child.prefix) {
+			return child
+		}
+	}
+	return nil
+}
+
+func (r *route) FindConflict(path string) error {
+	currentRoute := r
+	rightPath := path[len(r.prefix):]
+
+	for rightPath != "" {
+		if hasParamPrefix(rightPath) || hasWildcardPrefix(rightPath) {
+			if err := currentRoute.conflictOnChild(segmentOf(rightPath)); err != nil {
+				walked := strings.TrimSuffix(path[:(len(path)-len(rightPath))], "/")
+				return fmt.Errorf("%w: %s at %s", perror.ErrAmbiguousRoute, err.Error(), rootIfEmpty(walked))
+			}
+		}
+
+		nextRoute := currentRoute.childOfPattern(rightPath)
+		if nextRoute == nil {
+			// new subtree cannot conflict
+			return nil
+		}
+		currentRoute = nextRoute
+		rightPath = rightPath[len(nextRoute.prefix):]
+	}
+
+	return nil
+}
+
+// check param going to be a child of r
+func (r *route) conflictOnChild(param string) error {
+	switch {
+	case hasParamPrefix(param):
+		_, constraint, _ := parseParamSegment(param)
+		for _, paramRoute := range r.paramChildren {
+			if paramRoute.prefix == param {
+				continue
+			}
+
+			if constraintString(paramRoute.constraint) == constraintString(constraint) {
+				return fmt.Errorf("%s conflicts with %s", param, paramRoute.prefix)
+			}
+		}
+
+		if constraint == nil && r.wildcardChild != nil {
+			return fmt.Errorf("%s overlaps %s", param, r.wildcardChild.prefix)
+		}
+	case hasWildcardPrefix(param):
+		if r.wildcardChild != nil && r.wildcardChild.prefix != param {
+			return fmt.Errorf("%s conflicts with %s", param, r.wildcardChild.prefix)
+		}
+
+		for _, paramRoute := range r.paramChildren {
+			if paramRoute.constraint == nil {
+				return fmt.Errorf("%s overlaps %s", param, paramRoute.prefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *route) Remove(path string) bool {
+	removed := false
+	for _, variant := range expandOptionalParams(path) {
+		removed = r.remove(variant) || removed
+	}
+	return removed
+}
+
+func (r *route) remove(path string) bool {
+	nodes := r.walkPattern(path)
+	if nodes == nil {
+		return false
+	}
+
+	currentRoute := nodes[len(nodes)-1]
+	if !currentRoute.registered {
+		return false
+	}
+	currentRoute.unregister()
+	delete(r.static, path)
+
+	// prune from leaf
+	// & merge static node w/ its only static child
+	for i := len(nodes) - 1; i > 0; i-- {
+		node, parent := nodes[i], nodes[i-1]
+		if node.registered || !node.isLeaf() {
+			parent.mergeChild(node)
+			break
+		}
+		parent.removeChild(node)
+	}
+	return true
+}
+
+func (r *route) isLeaf() bool {
+	return len(r.children) == 0 && len(r.paramChildren) == 0 && r.wildcardChild == nil
+}
+
+// "users/" -> "find" => "users/find"
+func (r *route) mergeChild(node *route) {
+	if node.registered || node.paramKey != "" || len(node.children) != 1 {
+		return
+	}
+
+	if len(node.paramChildren) != 0 || node.wildcardChild != nil {
+		return
+	}
+
+	id := slices.Index(r.children, node)
+	if id < 0 {
+		return
+	}
+
+	// registered child keeps its pointer
+	child := node.children[0]
+	child.prefix = node.prefix + child.prefix
+	r.children[id] = child
+}
+
+func (r *route) unregister() {
+	r.handler = nil
+	r.registered = false
+	r.pattern = ""
+	r.path = ""
+	r.chain = nil
+	r.name = ""
+	r.metadata = nil
+	r.middlewares = nil
+	r.errorHandler = nil
+}
+
+func (r *route) removeChild(node *route) {
+	if r.wildcardChild == node {
+		r.wildcardChild = nil
+		return
+	}
+
+	r.paramChildren = slices.DeleteFunc(r.paramChildren, func(paramRoute *route) bool {
+		return paramRoute == node
+	})
+
+	if id := slices.Index(r.children, node); id >= 0 {
+		r.children = slices.Delete(r.children, id, id+1)
+		r.indices = r.indices[:id] + r.indices[(id+1):]
+	}
+}
+
+// deep copy of the subtree
+// handler & metadata are shared
+func (r *route) clone() *route {
+	cloned := *r
+	cloned.children = make([]*route, len(r.children))
+	for i, child := range r.children {
+		cloned.children[i] = child.clone()
+	}
+
+	cloned.paramChildren = make([]*route, len(r.paramChildren))
+	for i, child := range r.paramChildren {
+		cloned.paramChildren[i] = child.clone()
+	}
+
+	if r.wildcardChild != nil {
+		cloned.wildcardChild = r.wildcardChild.clone()
+	}
+
+	if r.static != nil {
+		cloned.static = make(map[string]*route, len(r.static))
+		for path := range r.static {
+			cloned.static[path] = cloned.Find(path)
+		}
+	}
+	return &cloned
+}
+
+// call fn w/ every node
+func (r *route) each(fn func(node *route)) {
+	fn(r)
+
+	for _, child := range r.children {
+		child.each(fn)
+	}
+
+	for _, child := range r.paramChildren {
+		child.each(fn)
+	}
+
+	if r.wildcardChild != nil {
+		r.wildcardChild.each(fn)
+	}
+}
+
+// call fn w/ every node which has handler
+func (r *route) eachHandler(fn func(node *route)) {
+	r.each(func(node *route) {
+		if node.handler != nil {
+			fn(node)
+		}
+	})
 }
 
 func (r *route) DFS() []routeLinear {
 	results := make([]routeLinear, 0)
 	visited := map[string]struct{}{}
-	r.dfs(r, "", &visited, &results)
+	r.dfs(r, r.prefix, &visited, &results)
 	return results
 }
 
@@ -154,21 +722,62 @@ func (r *route) dfs(
 
 	if node.handler != nil {
 		*results = append(*results, routeLinear{
-			path:    path,
-			handler: node.handler,
+			path:         rootIfEmpty(path),
+			handler:      node.handler,
+			pattern:      node.pattern,
+			name:         node.name,
+			metadata:     node.metadata,
+			middlewares:  node.middlewares,
+			errorHandler: node.errorHandler,
 		})
 	}
 
-	for key, child := range node.children {
-		nextPath := path + "/" + key
-		r.dfs(child.(*route), nextPath, visited, results)
+	for _, child := range node.children {
+		r.dfs(child, path+child.prefix, visited, results)
+	}
+
+	for _, child := range node.paramChildren {
+		r.dfs(child, path+child.prefix, visited, results)
+	}
+
+	if node.wildcardChild != nil {
+		r.dfs(node.wildcardChild, path+node.wildcardChild.prefix, visited, results)
 	}
 }
 
+// root is visited as "" on dfs
+func rootIfEmpty(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func constraintString(constraint *regexp.Regexp) string {
+	if constraint == nil {
+		return ""
+	}
+	return constraint.String()
+}
+
 func hasParamPrefix(param string) bool {
 	return strings.HasPrefix(param, constant.ParamPrefix)
 }
 
+func hasWildcardPrefix(param string) bool {
+	return strings.HasPrefix(param, constant.WildcardPrefix)
+}
+
+// "*filepath" -> ":filepath"
+// so that ctx.PathParam("filepath") can get captured value
+func wildcardParamKey(param string) string {
+	return constant.ParamPrefix + strings.TrimPrefix(param, constant.WildcardPrefix)
+}
+
+// nil safe
 func (r *route) GetHandler() HandlerFunc {
+	if r == nil {
+		return nil
+	}
 	return r.handler
 }
@@ -46,3 +46,62 @@ func BenchmarkInsertAndSearch(b *testing.B) {
 		}
 	}
 }
+
+var routeSearchBenchmarks = []struct {
+	name string
+	path string
+}{
+	{"Static", "/users/find"},
+	{"DeepStatic", "/example.com/v1/members/create/jagaimo"},
+	{"Param", "/users/1"},
+	{"TwoParams", "/users/1/posts/2"},
+	{"Constraint", "/items/1"},
+	{"Wildcard", "/static/css/main.css"},
+}
+
+func setupRouteForBenchmark() *route {
+	mockFunc := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+	rt.Insert("/", mockFunc)
+	rt.Insert("/users", mockFunc)
+	rt.Insert("/users/find", mockFunc)
+	rt.Insert("/users/:id", mockFunc)
+	rt.Insert("/users/:id/posts/:postId", mockFunc)
+	rt.Insert("/items/:id<int>", mockFunc)
+	rt.Insert("/example.com/v1/members/create/jagaimo", mockFunc)
+	rt.Insert("/static/*filepath", mockFunc)
+	return rt
+}
+
+func BenchmarkRouteSearch(b *testing.B) {
+	rt := setupRouteForBenchmark()
+
+	for _, it := range routeSearchBenchmarks {
+		b.Run(it.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				rt.Search(it.path)
+			}
+		})
+	}
+}
+
+// search on request w/ pooled params
+func BenchmarkRouteLookup(b *testing.B) {
+	rt := setupRouteForBenchmark()
+
+	for _, it := range routeSearchBenchmarks {
+		b.Run(it.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				params := acquireRouteParams()
+				rt.lookup(it.path, *params)
+				releaseRouteParams(params)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package poteto
+
+import (
+	"slices"
+)
+
+// handler of route w/ middlewares applied
+//
+// compiled once on update of route table
+// so that request does not search & apply middlewares.
+// it is valid only for the trees & revisions it was compiled with
+type routeChain struct {
+	tree MiddlewareTree
+	// middleware tree of default router applied before host one
+	// nil if route is not of host
+	parentTree     MiddlewareTree
+	revision       uint64
+	parentRevision uint64
+	compiled       HandlerFunc
+}
+
+// nil if chain is stale
+// middleware tree may be changed directly ex: node returned by Poteto.Combine
+func (rc *routeChain) handlerFor(tree, parentTree MiddlewareTree) HandlerFunc {
+	if !rc.isCompiledFor(tree, parentTree) {
+		return nil
+	}
+	return rc.compiled
+}
+
+func (rc *routeChain) isCompiledFor(tree, parentTree MiddlewareTree) bool {
+	if rc == nil || rc.tree != tree || rc.parentTree != parentTree {
+		return false
+	}
+
+	if rc.revision != tree.revision() {
+		return false
+	}
+
+	return parentTree == nil || rc.parentRevision == parentTree.revision()
+}
+
+// trees & revisions chains of table are compiled for
+func newChainStamp(tree, parentTree MiddlewareTree) *routeChain {
+	stamp := &routeChain{
+		tree:       tree,
+		parentTree: parentTree,
+		revision:   tree.revision(),
+	}
+	if parentTree != nil {
+		stamp.parentRevision = parentTree.revision()
+	}
+	return stamp
+}
+
+// compile chain of every route whose chain is stale
+// route depending on requested path is left to search on request
+// ex: "/users/:id" w/ "/users/me" middleware
+//
+// router shared w/ published table is copied only if trees changed
+func (p *poteto) compileChains(table *routeTable, parentTree MiddlewareTree) {
+	tree := table.middlewareTree
+	if !table.ownsRouter && table.chains.isCompiledFor(tree, parentTree) {
+		return
+	}
+
+	table.cloneRouter()
+	table.chains = newChainStamp(tree, parentTree)
+	for _, method := range table.router.Methods() {
+		routes := table.router.GetRoutesByMethod(method)
+		if routes == nil {
+			continue
+		}
+
+		routes.eachHandler(func(node *route) {
+			if node.chain.handlerFor(tree, parentTree) != nil {
+				return
+			}
+
+			node.chain = p.compileChain(node, tree, parentTree)
+		})
+	}
+}
+
+func (p *poteto) compileChain(node *route, tree, parentTree MiddlewareTree) *routeChain {
+	if node.path == "" || !tree.isStaticFor(node.path) {
+		return nil
+	}
+
+	middlewares := tree.SearchMiddlewares(node.path)
+	chain := &routeChain{
+		tree:     tree,
+		revision: tree.revision(),
+	}
+
+	if parentTree != nil {
+		if !parentTree.isStaticFor(node.path) {
+			return nil
+		}
+
+		middlewares = slices.Concat(parentTree.SearchMiddlewares(node.path), middlewares)
+		chain.parentTree = parentTree
+		chain.parentRevision = parentTree.revision()
+	}
+
+	middlewares = slices.Concat(middlewares, node.middlewares)
+	chain.compiled = p.applyMiddleware(middlewares, node.handler)
+	return chain
+}
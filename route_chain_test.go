@@ -0,0 +1,121 @@
+package poteto
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countingMiddlewareForTest(count *int) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		*count++
+		return func(ctx Context) error {
+			ctx.SetResponseHeader("Hello", "world")
+			return next(ctx)
+		}
+	}
+}
+
+func TestPoteto_CompiledChain(t *testing.T) {
+	// Arrange
+	count := 0
+	p := New()
+	p.Register(countingMiddlewareForTest(&count))
+	p.GET("/users/:id", getAllUserForTestById)
+	compiled := count
+
+	// Act
+	res1 := p.Play(http.MethodGet, "/users/1")
+	res2 := p.Play(http.MethodGet, "/users/2")
+
+	// Assert
+	assert.Equal(t, "world", res1.Header().Get("Hello"))
+	assert.Equal(t, "world", res2.Header().Get("Hello"))
+	assert.Equal(t, compiled, count)
+}
+
+func TestPoteto_CompiledChainIsRecompiled(t *testing.T) {
+	t.Run("on change of middleware tree", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.GET("/users", getAllUserForTest)
+		p.Combine("/users", sampleMiddleware)
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, "world", res.Header().Get("Hello"))
+	})
+
+	t.Run("on direct change of middleware tree", func(t *testing.T) {
+		// Arrange
+		p := New()
+		group := p.Combine("/users")
+		p.GET("/users", getAllUserForTest)
+		group.Register(sampleMiddleware)
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, "world", res.Header().Get("Hello"))
+	})
+
+	t.Run("on replace of route", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Register(sampleMiddleware)
+		p.GET("/users", getAllUserForTest)
+		p.ReplaceRoute(http.MethodGet, "/users", func(ctx Context) error {
+			return ctx.JSON(http.StatusOK, map[string]string{"replaced": "true"})
+		})
+
+		// Act
+		res := p.Play(http.MethodGet, "/users")
+
+		// Assert
+		assert.Equal(t, "world", res.Header().Get("Hello"))
+		assert.JSONEq(t, `{"replaced":"true"}`, res.Body.String())
+	})
+
+	t.Run("on change of default router for host", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.Host("admin.example.com", func(leaf Leaf) {
+			leaf.GET("/users", getAllUserForTest)
+		})
+		p.Register(sampleMiddleware)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Host = "admin.example.com"
+
+		// Act
+		p.ServeHTTP(w, req)
+		table := p.(*poteto).table.Load()
+		hostTable, _ := table.matchHost(req.Host)
+		hostRoute, _ := hostTable.router.GetRoutesByMethod(http.MethodGet).Search("/users")
+
+		// Assert
+		assert.Equal(t, "world", w.Header().Get("Hello"))
+		assert.NotNil(t, hostRoute.chain.handlerFor(hostTable.middlewareTree, table.middlewareTree))
+	})
+}
+
+func TestPoteto_ChainDependingOnPath(t *testing.T) {
+	// Arrange
+	p := New()
+	p.Combine("/users/me", sampleMiddleware)
+	p.GET("/users/:id", getAllUserForTestById)
+
+	// Act
+	resMe := p.Play(http.MethodGet, "/users/me")
+	resOther := p.Play(http.MethodGet, "/users/1")
+
+	// Assert
+	assert.Equal(t, "world", resMe.Header().Get("Hello"))
+	assert.Equal(t, "", resOther.Header().Get("Hello"))
+}
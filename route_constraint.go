@@ -0,0 +1,71 @@
+package poteto
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
+)
+
+// Built-in named constraints of path param
+//
+// "/users/:id<int>" is same as "/users/:id<[0-9]+>"
+var namedParamConstraints = map[string]string{
+	"int":   `[0-9]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"slug":  `[a-z0-9]+(?:-[a-z0-9]+)*`,
+	"alpha": `[a-zA-Z]+`,
+}
+
+// split param segment into param key & compiled constraint
+//
+// ":id" -> (":id", nil)
+// ":id<int>" -> (":id", ^(?:[0-9]+)$)
+// ":id<[0-9]+>" -> (":id", ^(?:[0-9]+)$)
+// ":id<int>?" -> (":id", ^(?:[0-9]+)$)
+//
+// constraint cannot include "/"
+func parseParamSegment(param string) (string, *regexp.Regexp, error) {
+	param = strings.TrimSuffix(param, constant.OptionalSuffix)
+	start := strings.Index(param, constant.ConstraintPrefix)
+	if start < 0 {
+		return param, nil, nil
+	}
+
+	if !strings.HasSuffix(param, constant.ConstraintSuffix) {
+		return "", nil, fmt.Errorf("%w: %s", perror.ErrInvalidParamConstraint, param)
+	}
+
+	key := param[:start]
+	expr := param[(start + 1):(len(param) - 1)]
+	if expr == "" {
+		return "", nil, fmt.Errorf("%w: %s", perror.ErrInvalidParamConstraint, param)
+	}
+
+	if named, ok := namedParamConstraints[expr]; ok {
+		expr = named
+	}
+
+	constraint, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %s", perror.ErrInvalidParamConstraint, param, err.Error())
+	}
+
+	return key, constraint, nil
+}
+
+// check all constraints in path can compile
+func validateParamConstraints(path string) error {
+	for _, param := range strings.Split(path, "/") {
+		if !hasParamPrefix(param) {
+			continue
+		}
+
+		if _, _, err := parseParamSegment(param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
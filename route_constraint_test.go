@@ -0,0 +1,82 @@
+package poteto
+
+import (
+	"testing"
+
+	"github.com/poteto-go/poteto/perror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParamSegment(t *testing.T) {
+	tests := []struct {
+		name        string
+		param       string
+		expectedKey string
+		matches     []string
+		unmatches   []string
+	}{
+		{"no constraint", ":id", ":id", nil, nil},
+		{"regexp constraint", ":id<[0-9]+>", ":id", []string{"1", "123"}, []string{"a", "1a", ""}},
+		{"int constraint", ":id<int>", ":id", []string{"42"}, []string{"-1", "4.2"}},
+		{
+			"uuid constraint",
+			":name<uuid>",
+			":name",
+			[]string{"0b5c1e4e-3c4b-4f3a-9d4e-1a2b3c4d5e6f"},
+			[]string{"0b5c1e4e", "0b5c1e4e-3c4b-4f3a-9d4e-1a2b3c4d5e6fa"},
+		},
+		{"slug constraint", ":slug<slug>", ":slug", []string{"hello-world", "a1"}, []string{"Hello", "hello--world", "-a"}},
+		{"alpha constraint", ":name<alpha>", ":name", []string{"poteto", "Poteto"}, []string{"poteto1"}},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			key, constraint, err := parseParamSegment(it.param)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, it.expectedKey, key)
+			if it.matches == nil && it.unmatches == nil {
+				assert.Nil(t, constraint)
+				return
+			}
+			for _, v := range it.matches {
+				assert.True(t, constraint.MatchString(v), v)
+			}
+			for _, v := range it.unmatches {
+				assert.False(t, constraint.MatchString(v), v)
+			}
+		})
+	}
+}
+
+func TestParseParamSegment_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+	}{
+		{"unclosed constraint", ":id<int"},
+		{"empty constraint", ":id<>"},
+		{"invalid regexp", ":id<[0-9>"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			_, _, err := parseParamSegment(it.param)
+
+			// Assert
+			assert.ErrorIs(t, err, perror.ErrInvalidParamConstraint)
+		})
+	}
+}
+
+func TestValidateParamConstraints(t *testing.T) {
+	assert.Nil(t, validateParamConstraints("/users/:id<int>/posts/:slug<slug>"))
+	assert.ErrorIs(
+		t,
+		validateParamConstraints("/users/:id<[a/b]>"),
+		perror.ErrInvalidParamConstraint,
+	)
+}
@@ -0,0 +1,79 @@
+package poteto
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Registered route info
+//
+// returned by Poteto.Routes
+type RouteInfo struct {
+	Method string
+	// host pattern registered by Poteto.Host
+	// empty on default router
+	Host string
+	Path string
+	// handler function name
+	// EX: "main.getUser"
+	Handler string
+	// named by poteto.WithName
+	Name string
+	// attached by poteto.WithMetadata
+	Metadata map[string]any
+	// function names of middlewares applied to the route
+	// in order of execution
+	Middlewares []string
+}
+
+func funcName(f any) string {
+	value := reflect.ValueOf(f)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(value.Pointer())
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// sort by host, path & method
+func sortRouteInfos(routeInfos []RouteInfo) {
+	sort.SliceStable(routeInfos, func(i, j int) bool {
+		if routeInfos[i].Host != routeInfos[j].Host {
+			return routeInfos[i].Host < routeInfos[j].Host
+		}
+		if routeInfos[i].Path != routeInfos[j].Path {
+			return routeInfos[i].Path < routeInfos[j].Path
+		}
+		return routeInfos[i].Method < routeInfos[j].Method
+	})
+}
+
+// METHOD  PATH        NAME  HANDLER       MIDDLEWARES
+// GET     /users/:id  user  main.getUser  main.logger
+//
+// route of host is printed as "admin.example.com/users"
+func formatRouteTable(routeInfos []RouteInfo) string {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	writer.Write([]byte("METHOD\tPATH\tNAME\tHANDLER\tMIDDLEWARES\n"))
+	for _, routeInfo := range routeInfos {
+		writer.Write([]byte(
+			routeInfo.Method + "\t" +
+				routeInfo.Host + routeInfo.Path + "\t" +
+				routeInfo.Name + "\t" +
+				routeInfo.Handler + "\t" +
+				strings.Join(routeInfo.Middlewares, ", ") + "\n",
+		))
+	}
+	writer.Flush()
+
+	return builder.String()
+}
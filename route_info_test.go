@@ -0,0 +1,51 @@
+package poteto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncName(t *testing.T) {
+	assert.Equal(t, "github.com/poteto-go/poteto.getAllUserForTest", funcName(getAllUserForTest))
+	assert.Equal(t, "github.com/poteto-go/poteto.sampleMiddleware", funcName(MiddlewareFunc(sampleMiddleware)))
+	assert.Equal(t, "", funcName(HandlerFunc(nil)))
+	assert.Equal(t, "", funcName("not func"))
+}
+
+func TestSortRouteInfos(t *testing.T) {
+	// Arrange
+	routeInfos := []RouteInfo{
+		{Method: "POST", Path: "/users"},
+		{Method: "GET", Path: "/users/:id"},
+		{Method: "GET", Path: "/users"},
+	}
+
+	// Act
+	sortRouteInfos(routeInfos)
+
+	// Assert
+	assert.Equal(t, []RouteInfo{
+		{Method: "GET", Path: "/users"},
+		{Method: "POST", Path: "/users"},
+		{Method: "GET", Path: "/users/:id"},
+	}, routeInfos)
+}
+
+func TestFormatRouteTable(t *testing.T) {
+	// Arrange
+	routeInfos := []RouteInfo{
+		{Method: "GET", Path: "/users/:id", Name: "user", Handler: "main.getUser", Middlewares: []string{"main.a", "main.b"}},
+		{Method: "POST", Path: "/users", Handler: "main.createUser", Middlewares: []string{}},
+	}
+
+	// Act
+	result := formatRouteTable(routeInfos)
+
+	// Assert
+	lines := strings.Split(result, "\n")
+	assert.Equal(t, "METHOD  PATH        NAME  HANDLER          MIDDLEWARES", lines[0])
+	assert.Equal(t, "GET     /users/:id  user  main.getUser     main.a, main.b", lines[1])
+	assert.Equal(t, "POST    /users            main.createUser  ", lines[2])
+}
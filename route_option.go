@@ -0,0 +1,80 @@
+package poteto
+
+// Option on route registration
+//
+//	p.GET("/users/:id", handler, poteto.WithName("user"))
+type RouteOption func(config *routeConfig)
+
+type routeConfig struct {
+	name     string
+	metadata map[string]any
+	// applied only to the route
+	// ex: middlewares of Leaf.Register
+	middlewares  []MiddlewareFunc
+	errorHandler ErrorHandlerFunc
+}
+
+// Name the route
+//
+// you can build url by name w/ Poteto.URL or Context.URL
+//
+//	p.GET("/users/:id", handler, poteto.WithName("user"))
+//	url, err := p.URL("user", "id", "1") // -> "/users/1"
+func WithName(name string) RouteOption {
+	return func(config *routeConfig) {
+		config.name = name
+	}
+}
+
+// Attach metadata to the route
+//
+// middleware can read it by Context.Route
+//
+//	p.GET("/admin", handler, poteto.WithMetadata("scope", "admin"))
+//
+//	func middleware(next poteto.HandlerFunc) poteto.HandlerFunc {
+//	  return func(ctx poteto.Context) error {
+//	    scope, ok := ctx.Route().Get("scope")
+//	    ...
+//	  }
+//	}
+func WithMetadata(key string, value any) RouteOption {
+	return func(config *routeConfig) {
+		if config.metadata == nil {
+			config.metadata = map[string]any{}
+		}
+		config.metadata[key] = value
+	}
+}
+
+// attach all of metadata
+func withMetadataMap(metadata map[string]any) RouteOption {
+	return func(config *routeConfig) {
+		for key, value := range metadata {
+			WithMetadata(key, value)(config)
+		}
+	}
+}
+
+// apply middlewares only to the route
+// they are applied after middlewares of middleware tree
+func withMiddlewares(middlewares []MiddlewareFunc) RouteOption {
+	return func(config *routeConfig) {
+		config.middlewares = append(config.middlewares, middlewares...)
+	}
+}
+
+// handle error of the route instead of Poteto.ErrorHandler
+func withErrorHandler(handler ErrorHandlerFunc) RouteOption {
+	return func(config *routeConfig) {
+		config.errorHandler = handler
+	}
+}
+
+func newRouteConfig(opts []RouteOption) routeConfig {
+	config := routeConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
@@ -0,0 +1,56 @@
+package poteto
+
+import (
+	"strings"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
+)
+
+// "/reports/:year/:month?" -> ["/reports/:year", "/reports/:year/:month"]
+// "/:lang?" -> ["/", "/:lang"]
+// path w/o optional param -> [path]
+//
+// optional params are validated by validateOptionalParams
+func expandOptionalParams(path string) []string {
+	if !strings.Contains(path, constant.OptionalSuffix) {
+		return []string{path}
+	}
+
+	segments := strings.Split(path[1:], "/")
+	variants := []string{}
+	required := []string{}
+	for _, segment := range segments {
+		if isOptionalParam(segment) {
+			variants = append(variants, "/"+strings.Join(required, "/"))
+			segment = strings.TrimSuffix(segment, constant.OptionalSuffix)
+		}
+		required = append(required, segment)
+	}
+
+	return append(variants, "/"+strings.Join(required, "/"))
+}
+
+// optional param must be trailing
+//
+// "/reports/:year?/:month?" -> ok
+// "/reports/:year?/list" -> error
+// "/static/*filepath?" -> error
+func validateOptionalParams(path string) error {
+	hasOptional := false
+	for _, segment := range strings.Split(path, "/") {
+		if isOptionalParam(segment) {
+			hasOptional = true
+			continue
+		}
+
+		if hasOptional || strings.HasSuffix(segment, constant.OptionalSuffix) {
+			return perror.ErrInvalidOptionalParam
+		}
+	}
+	return nil
+}
+
+func isOptionalParam(segment string) bool {
+	return hasParamPrefix(segment) && strings.HasSuffix(segment, constant.OptionalSuffix)
+}
@@ -0,0 +1,63 @@
+package poteto
+
+import (
+	"testing"
+
+	"github.com/poteto-go/poteto/perror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandOptionalParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{"no optional param", "/users/:id", []string{"/users/:id"}},
+		{"optional param", "/reports/:year/:month?", []string{"/reports/:year", "/reports/:year/:month"}},
+		{
+			"two optional params",
+			"/reports/:year?/:month<int>?",
+			[]string{"/reports", "/reports/:year", "/reports/:year/:month<int>"},
+		},
+		{"optional param on root", "/:lang?", []string{"/", "/:lang"}},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := expandOptionalParams(it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestValidateOptionalParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		isValid bool
+	}{
+		{"no optional param", "/users/:id", true},
+		{"trailing optional params", "/reports/:year?/:month?", true},
+		{"static after optional param", "/reports/:year?/list", false},
+		{"required param after optional param", "/reports/:year?/:month", false},
+		{"optional wildcard", "/static/*filepath?", false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			err := validateOptionalParams(it.path)
+
+			// Assert
+			if it.isValid {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, perror.ErrInvalidOptionalParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package poteto
+
+import (
+	"slices"
+)
+
+// snapshot of routing state
+//
+// published table is never mutated.
+// update is applied to clone & swapped atomically
+// so that in-flight request sees consistent table w/o lock.
+type routeTable struct {
+	router         Router
+	middlewareTree MiddlewareTree
+	// static host first, then host w/ param
+	hosts []*hostRoute
+	// router is not shared w/ published table & safe to mutate
+	ownsRouter bool
+	// trees & revisions chains of router are compiled for
+	chains *routeChain
+}
+
+func newRouteTable(rtr Router, mt MiddlewareTree) *routeTable {
+	return &routeTable{
+		router:         rtr,
+		middlewareTree: mt,
+		hosts:          []*hostRoute{},
+		ownsRouter:     true,
+	}
+}
+
+// router & middleware tree are shared, copy them before mutation
+func (t *routeTable) clone() *routeTable {
+	return &routeTable{
+		router:         t.router,
+		middlewareTree: t.middlewareTree,
+		hosts:          slices.Clone(t.hosts),
+		chains:         t.chains,
+	}
+}
+
+// return table of matched host & captured host params
+// fall back to t itself
+func (t *routeTable) matchHost(requestHost string) (*routeTable, []ParamUnit) {
+	if len(t.hosts) == 0 {
+		return t, nil
+	}
+
+	host := normalizeHost(requestHost)
+	for _, hostRoute := range t.hosts {
+		if hostParams, ok := hostRoute.match(host); ok {
+			return hostRoute.app.table.Load(), hostParams
+		}
+	}
+	return t, nil
+}
+
+// copy router before mutation
+// router is copied once per table
+func (t *routeTable) cloneRouter() Router {
+	if !t.ownsRouter {
+		t.router = t.router.clone()
+		t.ownsRouter = true
+	}
+	return t.router
+}
+
+// copy router even if table owns it
+// so that failed update does not leave partial change
+// ex: route is added to all methods or none
+func (t *routeTable) copyRouter() Router {
+	t.router = t.router.clone()
+	t.ownsRouter = true
+	return t.router
+}
+
+// copy middleware tree before mutation
+func (t *routeTable) cloneMiddlewareTree() MiddlewareTree {
+	t.middlewareTree = t.middlewareTree.clone()
+	return t.middlewareTree
+}
+
+// parentTree is middleware tree of default router applied before host one
+func (t *routeTable) routeInfos(host string, parentTree MiddlewareTree) []RouteInfo {
+	routeInfos := []RouteInfo{}
+	for _, method := range t.router.Methods() {
+		for _, lr := range t.router.DFS(method) {
+			middlewares := t.middlewareTree.SearchMiddlewares(lr.path)
+			if parentTree != nil {
+				middlewares = slices.Concat(parentTree.SearchMiddlewares(lr.path), middlewares)
+			}
+			middlewares = slices.Concat(middlewares, lr.middlewares)
+
+			// last one wraps others & runs first on applyMiddleware
+			middlewareNames := []string{}
+			for _, middleware := range slices.Backward(middlewares) {
+				middlewareNames = append(middlewareNames, funcName(middleware))
+			}
+
+			routeInfos = append(routeInfos, RouteInfo{
+				Method:      method,
+				Host:        host,
+				Path:        lr.path,
+				Handler:     funcName(lr.handler),
+				Name:        lr.name,
+				Metadata:    lr.metadata,
+				Middlewares: middlewareNames,
+			})
+		}
+	}
+	return routeInfos
+}
@@ -1,8 +1,11 @@
 package poteto
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/poteto-go/poteto/perror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,3 +84,381 @@ func TestRoute_DFS(t *testing.T) {
 		assert.Equal(t, 4, len(results))
 	})
 }
+
+func TestRoute_SearchWildcard(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	route := NewRoute().(*route)
+	route.Insert("/static/*filepath", mockFunc)
+	route.Insert("/static/index.html", mockFunc)
+	route.Insert("/static/js/app.js", mockFunc)
+	route.Insert("/users/:id", mockFunc)
+	route.Insert("/users/*rest", mockFunc)
+
+	tests := []struct {
+		name          string
+		arg           string
+		expectedKey   string
+		expectedValue string
+	}{
+		{"capture single segment", "/static/main.css", ":filepath", "main.css"},
+		{"capture remainder", "/static/css/theme/main.css", ":filepath", "css/theme/main.css"},
+		{"static has priority", "/static/index.html", "", ""},
+		{"fall back to wildcard from static", "/static/js/other.js", ":filepath", "js/other.js"},
+		{"fall back to wildcard from intermediate", "/static/js", ":filepath", "js"},
+		{"param has priority", "/users/1", ":id", "1"},
+		{"fall back to wildcard from param", "/users/1/posts", ":rest", "1/posts"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			found, params := route.Search(it.arg)
+
+			// Assert
+			assert.NotNil(t, found)
+			assert.NotNil(t, found.GetHandler())
+			if it.expectedKey == "" {
+				assert.Equal(t, 0, len(params))
+				return
+			}
+			assert.Equal(t, []ParamUnit{{it.expectedKey, it.expectedValue}}, params)
+		})
+	}
+}
+
+func TestRoute_Find(t *testing.T) {
+	// Arrange
+	route := NewRoute().(*route)
+	route.Insert("/users/:id", nil)
+	route.Insert("/static/*filepath", nil)
+
+	// Act & Assert
+	assert.NotNil(t, route.Find("/"))
+	assert.NotNil(t, route.Find("/users/:id"))
+	assert.NotNil(t, route.Find("/static/*filepath"))
+	assert.Nil(t, route.Find("/users/1"))
+	assert.Nil(t, route.Find("/static/main.css"))
+}
+
+func TestRoute_DFSWildcard(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	route := NewRoute().(*route)
+	route.Insert("/static/*filepath", mockFunc)
+
+	// Act
+	results := route.DFS()
+
+	// Assert
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "/static/*filepath", results[0].path)
+}
+
+func TestRoute_SearchConstraint(t *testing.T) {
+	// Arrange
+	byId := func(ctx Context) error { return nil }
+	byName := func(ctx Context) error { return nil }
+	byAny := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+	rt.Insert("/users/:key", byAny)
+	rt.Insert("/users/:id<int>", byId)
+	rt.Insert("/users/:name<alpha>", byName)
+	rt.Insert("/files/:name<uuid>", byId)
+
+	tests := []struct {
+		name          string
+		arg           string
+		expected      HandlerFunc
+		expectedParam ParamUnit
+	}{
+		{"int constraint", "/users/1", byId, ParamUnit{":id", "1"}},
+		{"alpha constraint", "/users/poteto", byName, ParamUnit{":name", "poteto"}},
+		{"fall through to unconstrained", "/users/poteto-1", byAny, ParamUnit{":key", "poteto-1"}},
+		{"uuid constraint", "/files/0b5c1e4e-3c4b-4f3a-9d4e-1a2b3c4d5e6f", byId, ParamUnit{":name", "0b5c1e4e-3c4b-4f3a-9d4e-1a2b3c4d5e6f"}},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			found, params := rt.Search(it.arg)
+
+			// Assert
+			assert.NotNil(t, found)
+			assert.Equal(t, reflect.ValueOf(it.expected).Pointer(), reflect.ValueOf(found.handler).Pointer())
+			assert.Equal(t, []ParamUnit{it.expectedParam}, params)
+		})
+	}
+
+	t.Run("not found if constraint unmatched", func(t *testing.T) {
+		// Act
+		found, _ := rt.Search("/files/unexpected")
+
+		// Assert
+		assert.Nil(t, found)
+	})
+
+	t.Run("constrained params come first", func(t *testing.T) {
+		// Act
+		params := []string{}
+		for _, paramRoute := range rt.Find("/users/").paramChildren {
+			params = append(params, paramRoute.prefix)
+		}
+
+		// Assert
+		assert.Equal(t, []string{":id<int>", ":name<alpha>", ":key"}, params)
+	})
+}
+
+func TestRoute_SearchCaseInsensitive(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	rt := NewRoute().(*route)
+	rt.Insert("/users/:id/Profile", mockFunc)
+
+	t.Run("case sensitive by default", func(t *testing.T) {
+		// Act
+		found, _ := rt.Search("/Users/Poteto/profile")
+
+		// Assert
+		assert.Nil(t, found)
+	})
+
+	t.Run("fold static segment & keep param", func(t *testing.T) {
+		// Arrange
+		rt.caseInsensitive = true
+		defer func() { rt.caseInsensitive = false }()
+
+		// Act
+		found, params := rt.Search("/Users/Poteto/profile")
+
+		// Assert
+		assert.NotNil(t, found)
+		assert.Equal(t, []ParamUnit{{":id", "Poteto"}}, params)
+	})
+}
+
+func TestRoute_FindConflict(t *testing.T) {
+	// Arrange
+	rt := NewRoute().(*route)
+	rt.Insert("/users/:id", nil)
+	rt.Insert("/users/:id<int>/posts", nil)
+	rt.Insert("/files/*filepath", nil)
+
+	tests := []struct {
+		name     string
+		path     string
+		conflict bool
+	}{
+		{"same param name", "/users/:id/name", false},
+		{"static sibling", "/users/find", false},
+		{"different constraint", "/users/:name<alpha>", false},
+		{"different param name", "/users/:name/posts", true},
+		{"different param name w/ same constraint", "/users/:uid<int>", true},
+		{"same wildcard", "/files/*filepath", false},
+		{"different wildcard name", "/files/*path", true},
+		{"param overlaps wildcard", "/files/:name", true},
+		{"constrained param w/ wildcard", "/files/:id<int>", false},
+		{"new subtree", "/items/:id", false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			err := rt.FindConflict(it.path)
+
+			// Assert
+			if it.conflict {
+				assert.ErrorIs(t, err, perror.ErrAmbiguousRoute)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRoute_Remove(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+	rt.Insert("/", mockFunc)
+	rt.Insert("/users/:id", mockFunc)
+	rt.Insert("/users/:id/posts/:postId", mockFunc)
+	rt.Insert("/files/*filepath", mockFunc)
+
+	// Act
+	removedPosts := rt.Remove("/users/:id/posts/:postId")
+	removedFiles := rt.Remove("/files/*filepath")
+	removedRoot := rt.Remove("/")
+	removedTwice := rt.Remove("/files/*filepath")
+	removedIntermediate := rt.Remove("/users")
+
+	// Assert
+	assert.True(t, removedPosts)
+	assert.True(t, removedFiles)
+	assert.True(t, removedRoot)
+	assert.False(t, removedTwice)
+	assert.False(t, removedIntermediate)
+
+	assert.Nil(t, rt.Find("/users/:id/posts"))
+	assert.Nil(t, rt.Find("/files"))
+	assert.Nil(t, rt.GetHandler())
+	assert.NotNil(t, rt.Find("/users/:id").GetHandler())
+	assert.True(t, rt.Find("/users/:id").isLeaf())
+}
+
+func TestRoute_Clone(t *testing.T) {
+	// Arrange
+	rt := NewRoute().(*route)
+	rt.Insert("/users/:id", nil)
+
+	// Act
+	cloned := rt.clone()
+	cloned.Insert("/users/:id/name", nil)
+	cloned.Remove("/users/:id")
+
+	// Assert
+	assert.True(t, rt.Find("/users/:id").registered)
+	assert.Nil(t, rt.Find("/users/:id/name"))
+	assert.False(t, cloned.Find("/users/:id").registered)
+	assert.NotNil(t, cloned.Find("/users/:id/name"))
+}
+
+func TestRoute_InsertOptional(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+
+	// Act
+	rt.Insert("/reports/:year/:month?", mockFunc)
+
+	// Assert
+	short, shortParams := rt.Search("/reports/2025")
+	full, fullParams := rt.Search("/reports/2025/06")
+	assert.NotNil(t, short.GetHandler())
+	assert.Equal(t, []ParamUnit{{":year", "2025"}}, shortParams)
+	assert.NotNil(t, full.GetHandler())
+	assert.Equal(t, []ParamUnit{{":year", "2025"}, {":month", "06"}}, fullParams)
+
+	paths := []string{}
+	for _, lr := range rt.DFS() {
+		paths = append(paths, lr.path)
+	}
+	assert.ElementsMatch(t, []string{"/reports/:year", "/reports/:year/:month"}, paths)
+
+	assert.True(t, rt.Remove("/reports/:year/:month?"))
+	assert.Nil(t, rt.Find("/reports"))
+}
+
+func TestRoute_Compress(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+	rt.Insert("/users/find", mockFunc)
+	found := rt.Find("/users/find")
+
+	t.Run("static path is one node", func(t *testing.T) {
+		// Assert
+		assert.Equal(t, 1, len(rt.children))
+		assert.Equal(t, "users/find", rt.children[0].prefix)
+	})
+
+	t.Run("split on common prefix", func(t *testing.T) {
+		// Act
+		rt.Insert("/users/friends", mockFunc)
+		rt.Insert("/users/:id", mockFunc)
+
+		// Assert
+		users := rt.Find("/users/")
+		assert.NotNil(t, users)
+		assert.Equal(t, "users/", rt.children[0].prefix)
+		assert.Equal(t, "f", users.children[0].prefix)
+		assert.Equal(t, 1, len(users.paramChildren))
+		assert.Same(t, found, rt.Find("/users/find"))
+	})
+
+	t.Run("merge on remove", func(t *testing.T) {
+		// Act
+		rt.Remove("/users/friends")
+		rt.Remove("/users/:id")
+
+		// Assert
+		assert.Equal(t, "users/find", rt.children[0].prefix)
+		assert.Same(t, found, rt.Find("/users/find"))
+	})
+}
+
+func TestRoute_SearchStatic(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error { return nil }
+	rt := NewRoute().(*route)
+	rt.Insert("/users/find", mockFunc)
+	rt.Insert("/users/:id", mockFunc)
+	rt.Insert("/admin", nil)
+
+	t.Run("registered w/o param", func(t *testing.T) {
+		// Assert
+		assert.Equal(t, 2, len(rt.static))
+		assert.Same(t, rt.Find("/users/find"), rt.static["/users/find"])
+	})
+
+	t.Run("found w/o params", func(t *testing.T) {
+		// Act
+		found, params := rt.Search("/users/find")
+
+		// Assert
+		assert.Same(t, rt.static["/users/find"], found)
+		assert.Nil(t, params)
+	})
+
+	t.Run("cloned route has own nodes", func(t *testing.T) {
+		// Act
+		cloned := rt.clone()
+
+		// Assert
+		assert.Same(t, cloned.Find("/users/find"), cloned.static["/users/find"])
+		assert.NotSame(t, rt.static["/users/find"], cloned.static["/users/find"])
+	})
+
+	t.Run("deleted on remove", func(t *testing.T) {
+		// Act
+		rt.Remove("/users/find")
+		found, params := rt.Search("/users/find")
+
+		// Assert
+		assert.Equal(t, 1, len(rt.static))
+		assert.Equal(t, []ParamUnit{{":id", "find"}}, params)
+		assert.Same(t, rt.Find("/users/:id"), found)
+	})
+}
+
+func TestRoute_SearchOverParamsCapacity(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error { return nil }
+	pattern, path := "", ""
+	expected := []ParamUnit{}
+	for i := 0; i <= routeParamsCapacity; i++ {
+		key := fmt.Sprintf(":p%d", i)
+		pattern += "/" + key
+		path += fmt.Sprintf("/%d", i)
+		expected = append(expected, ParamUnit{key, fmt.Sprint(i)})
+	}
+	rt := NewRoute().(*route)
+	rt.Insert(pattern, mockFunc)
+
+	// Act
+	params := acquireRouteParams()
+	found, httpParams := rt.lookup(path, *params)
+
+	// Assert
+	assert.NotNil(t, found.GetHandler())
+	assert.Equal(t, expected, httpParams)
+	assert.Equal(t, routeParamsCapacity, cap(*params))
+	releaseRouteParams(params)
+}
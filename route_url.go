@@ -0,0 +1,115 @@
+package poteto
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/poteto-go/poteto/perror"
+)
+
+// build url from registered pattern
+//
+//	buildURL("/users/:id", {"id": "1"}) -> "/users/1"
+//	buildURL("/static/*filepath", {"filepath": "css/main.css"}) -> "/static/css/main.css"
+//	buildURL("/reports/:year/:month?", {"year": "2025"}) -> "/reports/2025"
+//
+// param value is escaped, wildcard value is escaped by segment
+func buildURL(pattern string, params map[string]string) (string, error) {
+	if pattern == "/" {
+		return pattern, nil
+	}
+
+	builder := strings.Builder{}
+	builder.Grow(len(pattern))
+
+	// optional param is omitted
+	// following optional params must be omitted too
+	omitted := ""
+	for _, segment := range strings.Split(pattern[1:], "/") {
+		switch {
+		case hasParamPrefix(segment):
+			key, constraint, err := parseParamSegment(segment)
+			if err != nil {
+				return "", err
+			}
+
+			name := key[1:]
+			value, ok := params[name]
+			if !ok && isOptionalParam(segment) {
+				if omitted == "" {
+					omitted = name
+				}
+				continue
+			}
+			if !ok {
+				return "", fmt.Errorf("%w: %s", perror.ErrMissingRouteParam, name)
+			}
+			if omitted != "" {
+				return "", fmt.Errorf("%w: %s", perror.ErrMissingRouteParam, omitted)
+			}
+
+			if constraint != nil && !constraint.MatchString(value) {
+				return "", fmt.Errorf("%w: %s=%s", perror.ErrUnmatchedRouteParam, name, value)
+			}
+
+			if isDotSegment(value) {
+				return "", fmt.Errorf("%w: %s=%s", perror.ErrUnmatchedRouteParam, name, value)
+			}
+
+			builder.WriteString("/")
+			builder.WriteString(url.PathEscape(value))
+		case hasWildcardPrefix(segment):
+			name := wildcardParamKey(segment)[1:]
+			value, ok := params[name]
+			if !ok {
+				return "", fmt.Errorf("%w: %s", perror.ErrMissingRouteParam, name)
+			}
+
+			// "../../etc" -> error
+			segments := strings.Split(value, "/")
+			if slices.ContainsFunc(segments, isDotSegment) {
+				return "", fmt.Errorf("%w: %s=%s", perror.ErrUnmatchedRouteParam, name, value)
+			}
+
+			builder.WriteString("/")
+			for i, v := range segments {
+				if i > 0 {
+					builder.WriteString("/")
+				}
+				builder.WriteString(url.PathEscape(v))
+			}
+		default:
+			builder.WriteString("/")
+			builder.WriteString(segment)
+		}
+	}
+
+	// "/:lang?" w/o lang
+	if builder.Len() == 0 {
+		return "/", nil
+	}
+	return builder.String(), nil
+}
+
+// "." & ".." are not escaped by url.PathEscape
+// so that they escape the route
+func isDotSegment(segment string) bool {
+	return segment == "." || segment == ".."
+}
+
+// "id", "1", "name", "poteto" -> {"id": "1", "name": "poteto"}
+func pairsToParams(pairs []string) (map[string]string, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf(
+			"%w: value of %s", perror.ErrMissingRouteParam, pairs[len(pairs)-1],
+		)
+	}
+
+	params := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		params[pairs[i]] = pairs[i+1]
+	}
+	return params, nil
+}
@@ -0,0 +1,86 @@
+package poteto
+
+import (
+	"testing"
+
+	"github.com/poteto-go/poteto/perror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		params   map[string]string
+		expected string
+	}{
+		{"root", "/", nil, "/"},
+		{"static", "/users", nil, "/users"},
+		{"param", "/users/:id", map[string]string{"id": "1"}, "/users/1"},
+		{"two params", "/users/:id/posts/:postId", map[string]string{"id": "1", "postId": "2"}, "/users/1/posts/2"},
+		{"escape param", "/users/:name", map[string]string{"name": "a b/c"}, "/users/a%20b%2Fc"},
+		{"constrained param", "/users/:id<int>", map[string]string{"id": "1"}, "/users/1"},
+		{"wildcard", "/static/*filepath", map[string]string{"filepath": "css/main file.css"}, "/static/css/main%20file.css"},
+		{"dots in wildcard", "/files/*path", map[string]string{"path": "a..b/.env"}, "/files/a..b/.env"},
+		{"optional param", "/reports/:year/:month?", map[string]string{"year": "2025", "month": "06"}, "/reports/2025/06"},
+		{"omit optional param", "/reports/:year/:month?", map[string]string{"year": "2025"}, "/reports/2025"},
+		{"omit optional param on root", "/:lang?", nil, "/"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result, err := buildURL(it.pattern, it.params)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestBuildURL_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		params   map[string]string
+		expected error
+	}{
+		{"missing param", "/users/:id", map[string]string{}, perror.ErrMissingRouteParam},
+		{"missing wildcard", "/static/*filepath", map[string]string{"id": "1"}, perror.ErrMissingRouteParam},
+		{"unmatched constraint", "/users/:id<int>", map[string]string{"id": "poteto"}, perror.ErrUnmatchedRouteParam},
+		{"optional param after omitted", "/reports/:year?/:month?", map[string]string{"month": "06"}, perror.ErrMissingRouteParam},
+		{"dot segment of wildcard", "/files/*path", map[string]string{"path": "../../etc"}, perror.ErrUnmatchedRouteParam},
+		{"current dir of wildcard", "/files/*path", map[string]string{"path": "a/./b"}, perror.ErrUnmatchedRouteParam},
+		{"dot segment of param", "/files/:name", map[string]string{"name": ".."}, perror.ErrUnmatchedRouteParam},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			_, err := buildURL(it.pattern, it.params)
+
+			// Assert
+			assert.ErrorIs(t, err, it.expected)
+		})
+	}
+}
+
+func TestPairsToParams(t *testing.T) {
+	t.Run("pairs", func(t *testing.T) {
+		// Act
+		result, err := pairsToParams([]string{"id", "1", "name", "poteto"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"id": "1", "name": "poteto"}, result)
+	})
+
+	t.Run("odd pairs", func(t *testing.T) {
+		// Act
+		_, err := pairsToParams([]string{"id", "1", "name"})
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrMissingRouteParam)
+	})
+}
@@ -2,12 +2,22 @@ package poteto
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"net/http"
+	"sort"
 	"strings"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
 )
 
 type Router interface {
-	add(method, path string, handler HandlerFunc) error
+	add(method, path string, handler HandlerFunc, opts ...RouteOption) error
+
+	// static segment is matched w/ case folding on every method
+	setCaseInsensitive(caseInsensitive bool)
+	isCaseInsensitive() bool
 
 	/*
 		Register GET method Route
@@ -15,7 +25,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	GET(path string, handler HandlerFunc) error
+	GET(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register POST method Route
@@ -23,7 +33,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	POST(path string, handler HandlerFunc) error
+	POST(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register PUT method Route
@@ -31,7 +41,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	PUT(path string, handler HandlerFunc) error
+	PUT(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register PATCH method Route
@@ -39,7 +49,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	PATCH(path string, handler HandlerFunc) error
+	PATCH(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register DELETE method Route
@@ -47,7 +57,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	DELETE(path string, handler HandlerFunc) error
+	DELETE(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register HEAD method Route
@@ -55,7 +65,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	HEAD(path string, handler HandlerFunc) error
+	HEAD(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register OPTIONS method Route
@@ -63,7 +73,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	OPTIONS(path string, handler HandlerFunc) error
+	OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register TRACE method Route
@@ -71,7 +81,7 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	TRACE(path string, handler HandlerFunc) error
+	TRACE(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	/*
 		Register CONNECT method Route
@@ -79,22 +89,107 @@ type Router interface {
 		Trim Suffix "/"
 		EX: "/users/" -> "/users"
 	*/
-	CONNECT(path string, handler HandlerFunc) error
+	CONNECT(path string, handler HandlerFunc, opts ...RouteOption) error
+
+	/*
+		Register Route of any method
+
+		Not standard method (ex: PROPFIND, PURGE) is also available
+		Trie for new method is created lazily
+
+		Trim Suffix "/"
+		EX: "/users/" -> "/users"
+	*/
+	Handle(method, path string, handler HandlerFunc, opts ...RouteOption) error
+
+	/*
+		Register Route of all standard methods
+		GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS, TRACE, CONNECT
+
+		poteto.WithName is not available because name is unique
+
+		Trim Suffix "/"
+		EX: "/users/" -> "/users"
+	*/
+	Any(path string, handler HandlerFunc, opts ...RouteOption) error
 
 	// DFS route & return linearRouter by method
 	//
 	// []{
 	//   path: string,
 	//   handler: HandlerFunc,
+	//   pattern: string,
+	//   name: string,
+	//   metadata: map[string]any,
 	// }
+	//
+	// optional param is reported as expanded routes
 	DFS(method string) []routeLinear
 
 	GetRoutesByMethod(method string) *route
+
+	// return registered methods sorted by name
+	Methods() []string
+
+	// return methods which have handler on the path
+	// sorted by method name
+	//
+	// EX: GET /users & POST /users are registered
+	//   GetAllowedMethods("/users") -> []string{"GET", "POST"}
+	GetAllowedMethods(path string) []string
+
+	// build url of the route named by poteto.WithName
+	// params are key-value pairs
+	//
+	// EX: GET /users/:id is named "user"
+	//   URL("user", "id", "1") -> "/users/1"
+	URL(name string, params ...string) (string, error)
+
+	// unregister route
+	// return perror.ErrRouteNotFound if not registered
+	//
+	// Trim Suffix "/"
+	// EX: "/users/" -> "/users"
+	Remove(method, path string) error
+
+	// deep copy of router
+	// used for copy-on-write update of poteto
+	clone() Router
+
+	// register route of every method incl. not standard one
+	// searched if route of requested method is not found
+	// ex: Poteto.Mount
+	addFallback(path string, handler HandlerFunc) error
+
+	// nil if no fallback is registered
+	fallbackRoutes() *route
+}
+
+// standard(net/http) methods
+var allHttpMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodConnect,
 }
 
 // Each Router has TrieTreeRouting by method
 type router struct {
 	routes map[string]Route
+	// routes of every method ex: Poteto.Mount
+	fallback        *route
+	names           map[string]namedRoute
+	caseInsensitive bool
+}
+
+type namedRoute struct {
+	method string
+	path   string
 }
 
 /*
@@ -103,6 +198,7 @@ Router Provides Radix-Tree Routing
 O(logN) ~ N
 
 Supports standard(net/http) methods GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS, TRACE, CONNECT
+Other methods (ex: PROPFIND, PURGE) are supported by Router.Handle
 
 You can use only Router of course.
 */
@@ -119,69 +215,163 @@ func NewRouter() Router {
 			http.MethodTrace:   NewRoute(),
 			http.MethodConnect: NewRoute(),
 		},
+		names: map[string]namedRoute{},
 	}
 }
 
-func (r *router) add(method, path string, handler HandlerFunc) error {
+func (r *router) add(method, path string, handler HandlerFunc, opts ...RouteOption) error {
 	routes := r.GetRoutesByMethod(method)
 	if routes == nil {
 		return errors.New("unexpected method error: " + method)
 	}
 
-	thisRoute, _ := routes.Search(path)
-	if thisRoute != nil {
-		if path == "/" {
-			thisRoute.handler = handler
-			return nil
-		}
-		return errors.New("[" + method + "] " + path + " is already used")
+	return r.insert(routes, method, path, handler, opts...)
+}
+
+// method is used for error & named route
+func (r *router) insert(routes *route, method, path string, handler HandlerFunc, opts ...RouteOption) error {
+	if err := validateWildcard(path); err != nil {
+		return fmt.Errorf("[%s] %s: %w", method, path, err)
+	}
+
+	if err := validateParamConstraints(path); err != nil {
+		return fmt.Errorf("[%s] %s: %w", method, path, err)
 	}
 
 	// "/users/" -> "/users"
-	// if just "/" -> handler set by above
-	path = strings.TrimSuffix(path, "/")
+	// if just "/" -> handler set by below
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	if err := validateOptionalParams(path); err != nil {
+		return fmt.Errorf("[%s] %s: %w", method, path, err)
+	}
+
+	config := newRouteConfig(opts)
+	if config.name != "" {
+		if _, ok := r.names[config.name]; ok {
+			return fmt.Errorf("[%s] %s: %w: %s", method, path, perror.ErrRouteNameAlreadyUsed, config.name)
+		}
+	}
+
+	// "/reports/:year/:month?" -> "/reports/:year" & "/reports/:year/:month"
+	variants := expandOptionalParams(path)
+	for _, variant := range variants {
+		if err := routes.FindConflict(variant); err != nil {
+			return fmt.Errorf("[%s] %s: %w", method, path, err)
+		}
+
+		if thisRoute := routes.Find(variant); thisRoute != nil && thisRoute.registered {
+			return fmt.Errorf("[%s] %s: %w", method, variant, perror.ErrRouteAlreadyUsed)
+		}
+	}
 
 	routes.Insert(path, handler)
+	for _, variant := range variants {
+		thisRoute := routes.Find(variant)
+		thisRoute.pattern = path
+		thisRoute.path = variant
+		thisRoute.name = config.name
+		thisRoute.metadata = config.metadata
+		thisRoute.middlewares = config.middlewares
+		thisRoute.errorHandler = config.errorHandler
+	}
+
+	if config.name != "" {
+		r.names[config.name] = namedRoute{method: method, path: path}
+	}
 	return nil
 }
 
+func (r *router) setCaseInsensitive(caseInsensitive bool) {
+	r.caseInsensitive = caseInsensitive
+	for _, routes := range r.routes {
+		routes.(*route).caseInsensitive = caseInsensitive
+	}
+	if r.fallback != nil {
+		r.fallback.caseInsensitive = caseInsensitive
+	}
+}
+
+func (r *router) isCaseInsensitive() bool {
+	return r.caseInsensitive
+}
+
+func (r *router) addFallback(path string, handler HandlerFunc) error {
+	if r.fallback == nil {
+		r.fallback = NewRoute().(*route)
+		r.fallback.caseInsensitive = r.caseInsensitive
+	}
+
+	return r.insert(r.fallback, "*", path, handler)
+}
+
+func (r *router) fallbackRoutes() *route {
+	return r.fallback
+}
+
 // These are router Method
 // Seems redundant, but you can register your own router with poteto
 // And call it with `Poteto.GET()` etc.
-func (r *router) GET(path string, handler HandlerFunc) error {
-	return r.add(http.MethodGet, path, handler)
+func (r *router) GET(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodGet, path, handler, opts...)
+}
+
+func (r *router) POST(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodPost, path, handler, opts...)
 }
 
-func (r *router) POST(path string, handler HandlerFunc) error {
-	return r.add(http.MethodPost, path, handler)
+func (r *router) PUT(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodPut, path, handler, opts...)
 }
 
-func (r *router) PUT(path string, handler HandlerFunc) error {
-	return r.add(http.MethodPut, path, handler)
+func (r *router) PATCH(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodPatch, path, handler, opts...)
 }
 
-func (r *router) PATCH(path string, handler HandlerFunc) error {
-	return r.add(http.MethodPatch, path, handler)
+func (r *router) DELETE(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodDelete, path, handler, opts...)
 }
 
-func (r *router) DELETE(path string, handler HandlerFunc) error {
-	return r.add(http.MethodDelete, path, handler)
+func (r *router) HEAD(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodHead, path, handler, opts...)
 }
 
-func (r *router) HEAD(path string, handler HandlerFunc) error {
-	return r.add(http.MethodHead, path, handler)
+func (r *router) OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodOptions, path, handler, opts...)
 }
 
-func (r *router) OPTIONS(path string, handler HandlerFunc) error {
-	return r.add(http.MethodOptions, path, handler)
+func (r *router) TRACE(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodTrace, path, handler, opts...)
 }
 
-func (r *router) TRACE(path string, handler HandlerFunc) error {
-	return r.add(http.MethodTrace, path, handler)
+func (r *router) CONNECT(path string, handler HandlerFunc, opts ...RouteOption) error {
+	return r.add(http.MethodConnect, path, handler, opts...)
 }
 
-func (r *router) CONNECT(path string, handler HandlerFunc) error {
-	return r.add(http.MethodConnect, path, handler)
+func (r *router) Handle(method, path string, handler HandlerFunc, opts ...RouteOption) error {
+	if !isValidMethod(method) {
+		return fmt.Errorf("%w: %s", perror.ErrUnSupportedHTTPMethod, method)
+	}
+
+	if _, ok := r.routes[method]; !ok {
+		routes := NewRoute().(*route)
+		routes.caseInsensitive = r.caseInsensitive
+		r.routes[method] = routes
+	}
+
+	return r.add(method, path, handler, opts...)
+}
+
+func (r *router) Any(path string, handler HandlerFunc, opts ...RouteOption) error {
+	errs := []error{}
+	for _, method := range allHttpMethods {
+		if err := r.add(method, path, handler, opts...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (r *router) DFS(method string) []routeLinear {
@@ -199,3 +389,120 @@ func (r *router) GetRoutesByMethod(method string) *route {
 	}
 	return nil
 }
+
+func (r *router) Methods() []string {
+	methods := make([]string, 0, len(r.routes))
+	for method := range r.routes {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+	return methods
+}
+
+func (r *router) GetAllowedMethods(path string) []string {
+	allowedMethods := make([]string, 0)
+	for method, routes := range r.routes {
+		targetRoute, _ := routes.Search(path)
+		if targetRoute == nil || targetRoute.GetHandler() == nil {
+			continue
+		}
+		allowedMethods = append(allowedMethods, method)
+	}
+
+	sort.Strings(allowedMethods)
+	return allowedMethods
+}
+
+func (r *router) Remove(method, path string) error {
+	routes := r.GetRoutesByMethod(method)
+	if routes == nil {
+		return fmt.Errorf("[%s] %s: %w", method, path, perror.ErrRouteNotFound)
+	}
+
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	for _, variant := range expandOptionalParams(path) {
+		thisRoute := routes.Find(variant)
+		if thisRoute == nil || !thisRoute.registered {
+			return fmt.Errorf("[%s] %s: %w", method, path, perror.ErrRouteNotFound)
+		}
+
+		if thisRoute.name != "" {
+			delete(r.names, thisRoute.name)
+		}
+	}
+
+	routes.Remove(path)
+	return nil
+}
+
+func (r *router) clone() Router {
+	routes := make(map[string]Route, len(r.routes))
+	for method, methodRoutes := range r.routes {
+		routes[method] = methodRoutes.(*route).clone()
+	}
+
+	var fallback *route
+	if r.fallback != nil {
+		fallback = r.fallback.clone()
+	}
+
+	return &router{
+		routes:          routes,
+		fallback:        fallback,
+		names:           maps.Clone(r.names),
+		caseInsensitive: r.caseInsensitive,
+	}
+}
+
+// catch-all segment must be the last segment
+// "/static/*filepath" -> ok
+// "/static/*filepath/edit" -> error
+func validateWildcard(path string) error {
+	id := strings.Index(path, "/"+constant.WildcardPrefix)
+	if id < 0 {
+		return nil
+	}
+
+	if strings.Contains(path[(id+1):], "/") {
+		return perror.ErrWildcardNotLast
+	}
+	return nil
+}
+
+func (r *router) URL(name string, params ...string) (string, error) {
+	named, ok := r.names[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", perror.ErrRouteNameNotFound, name)
+	}
+
+	paramMap, err := pairsToParams(params)
+	if err != nil {
+		return "", err
+	}
+
+	return buildURL(named.path, paramMap)
+}
+
+// method is token of RFC 9110
+// https://www.rfc-editor.org/rfc/rfc9110#name-tokens
+func isValidMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+
+	for _, c := range method {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			continue
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
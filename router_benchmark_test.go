@@ -2,6 +2,7 @@ package poteto
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -74,3 +75,98 @@ func BenchmarkInsertAndSearchRouter(b *testing.B) {
 		}
 	}
 }
+
+func nopMiddlewareForBenchmark(next HandlerFunc) HandlerFunc {
+	return func(ctx Context) error {
+		return next(ctx)
+	}
+}
+
+func setupMiddlewareChainForBenchmark() *poteto {
+	p := New().(*poteto)
+	p.Register(nopMiddlewareForBenchmark, nopMiddlewareForBenchmark)
+	p.Combine("/users", nopMiddlewareForBenchmark, nopMiddlewareForBenchmark)
+	p.Combine("/users/:id", nopMiddlewareForBenchmark)
+	p.GET("/users/:id", func(ctx Context) error {
+		return nil
+	})
+	return p
+}
+
+func BenchmarkMiddlewareChain(b *testing.B) {
+	p := setupMiddlewareChainForBenchmark()
+	table := p.table.Load()
+	targetRoute, _ := p.searchRoute(table.router, http.MethodGet, "/users/1", nil)
+	ctx := NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	// search & apply middlewares on every request
+	b.Run("Search", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			middlewares := table.middlewareTree.SearchMiddlewares("/users/1")
+			handler := p.applyMiddleware(middlewares, targetRoute.handler)
+			handler(ctx)
+		}
+	})
+
+	// chain compiled on update of route table
+	b.Run("Compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			handler := targetRoute.chain.handlerFor(table.middlewareTree, nil)
+			handler(ctx)
+		}
+	})
+}
+
+func BenchmarkServeHTTPWithMiddlewareChain(b *testing.B) {
+	p := setupMiddlewareChainForBenchmark()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkServeHTTPRouting(b *testing.B) {
+	p := New()
+	handler := func(ctx Context) error {
+		return ctx.NoContent()
+	}
+	p.GET("/users/find", handler)
+	p.GET("/users/:id", handler)
+	p.GET("/users/:id/posts/:postId", handler)
+	p.GET("/static/*filepath", handler)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"Static", "/users/find"},
+		{"Param", "/users/1"},
+		{"TwoParams", "/users/1/posts/2"},
+		{"Wildcard", "/static/css/main.css"},
+	}
+
+	for _, it := range tests {
+		b.Run(it.name, func(b *testing.B) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, it.path, nil)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				p.ServeHTTP(w, req)
+			}
+		})
+	}
+}
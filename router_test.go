@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/poteto-go/poteto/perror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,14 +51,8 @@ func TestGetRoutesByMethod(t *testing.T) {
 	rtr.GET("/users/get", nil)
 
 	routes := rtr.GetRoutesByMethod("GET")
-	child, ok := routes.children["users"].(*route)
-	if !ok {
-		t.Errorf("FATAL add top param")
-	}
-
-	_, ok = child.children["get"].(*route)
-	if !ok {
-		t.Errorf("FATAL add bottom param")
+	if routes.Find("/users/get") == nil {
+		t.Errorf("FATAL add route")
 	}
 }
 
@@ -77,3 +72,305 @@ func TestRouter_DFS(t *testing.T) {
 		assert.Equal(t, 1, len(got))
 	})
 }
+
+func TestRouter_AddWildcard(t *testing.T) {
+	t.Run("wildcard must be last segment", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+
+		// Act
+		err := rtr.GET("/static/*filepath/edit", nil)
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrWildcardNotLast)
+	})
+
+	t.Run("can register static sibling of wildcard", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+
+		// Act
+		errWildcard := rtr.GET("/static/*filepath", nil)
+		errStatic := rtr.GET("/static/index.html", nil)
+
+		// Assert
+		assert.Nil(t, errWildcard)
+		assert.Nil(t, errStatic)
+	})
+
+	t.Run("can register static sibling of param", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+
+		// Act
+		errParam := rtr.GET("/users/:id", nil)
+		errStatic := rtr.GET("/users/me", nil)
+
+		// Assert
+		assert.Nil(t, errParam)
+		assert.Nil(t, errStatic)
+	})
+}
+
+func TestRouter_AddConstraint(t *testing.T) {
+	// Arrange
+	rtr := NewRouter().(*router)
+
+	// Act
+	errValid := rtr.GET("/users/:id<int>", nil)
+	errInvalid := rtr.GET("/users/:id<[0-9>", nil)
+
+	// Assert
+	assert.Nil(t, errValid)
+	assert.ErrorIs(t, errInvalid, perror.ErrInvalidParamConstraint)
+}
+
+func TestRouter_GetAllowedMethods(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	rtr := NewRouter().(*router)
+	rtr.POST("/users", mockFunc)
+	rtr.GET("/users", mockFunc)
+	rtr.DELETE("/users/:id", mockFunc)
+	rtr.PUT("/users/:id/name", mockFunc)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{"sorted methods", "/users", []string{http.MethodGet, http.MethodPost}},
+		{"param route", "/users/1", []string{http.MethodDelete}},
+		{"intermediate route does not count", "/users/1/name", []string{http.MethodPut}},
+		{"not found", "/unexpected", []string{}},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := rtr.GetAllowedMethods(it.path)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
+
+func TestRouter_URL(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	rtr := NewRouter().(*router)
+	rtr.GET("/users/:id", mockFunc, WithName("user"))
+	rtr.POST("/", mockFunc, WithName("root"))
+
+	t.Run("build url by name", func(t *testing.T) {
+		// Act
+		userURL, errUser := rtr.URL("user", "id", "1")
+		rootURL, errRoot := rtr.URL("root")
+
+		// Assert
+		assert.Nil(t, errUser)
+		assert.Equal(t, "/users/1", userURL)
+		assert.Nil(t, errRoot)
+		assert.Equal(t, "/", rootURL)
+	})
+
+	t.Run("name not found", func(t *testing.T) {
+		// Act
+		_, err := rtr.URL("unexpected")
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrRouteNameNotFound)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		// Act
+		err := rtr.PUT("/users/:id", nil, WithName("user"))
+
+		// Assert
+		assert.ErrorIs(t, err, perror.ErrRouteNameAlreadyUsed)
+		assert.Nil(t, rtr.GetRoutesByMethod(http.MethodPut).Find("/users/:id"))
+	})
+
+	t.Run("name is stored on route", func(t *testing.T) {
+		// Act
+		result := rtr.DFS(http.MethodGet)
+
+		// Assert
+		assert.Equal(t, "user", result[0].name)
+	})
+}
+
+func TestRouter_Handle(t *testing.T) {
+	t.Run("standard method", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+
+		// Act
+		err := rtr.Handle(http.MethodGet, "/users", nil)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.NotNil(t, rtr.GetRoutesByMethod(http.MethodGet).Find("/users"))
+	})
+
+	t.Run("create trie of custom method lazily", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+		rtr.setCaseInsensitive(true)
+
+		// Act
+		errPropfind := rtr.Handle("PROPFIND", "/files", nil)
+		errMkcol := rtr.Handle("MKCOL", "/files/:name", nil)
+
+		// Assert
+		assert.Nil(t, errPropfind)
+		assert.Nil(t, errMkcol)
+		assert.NotNil(t, rtr.GetRoutesByMethod("PROPFIND").Find("/files"))
+		assert.True(t, rtr.GetRoutesByMethod("PROPFIND").caseInsensitive)
+		assert.Contains(t, rtr.Methods(), "MKCOL")
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+
+		// Act
+		errEmpty := rtr.Handle("", "/files", nil)
+		errSpace := rtr.Handle("PROP FIND", "/files", nil)
+
+		// Assert
+		assert.ErrorIs(t, errEmpty, perror.ErrUnSupportedHTTPMethod)
+		assert.ErrorIs(t, errSpace, perror.ErrUnSupportedHTTPMethod)
+	})
+}
+
+func TestRouter_Any(t *testing.T) {
+	t.Run("register all standard methods", func(t *testing.T) {
+		// Arrange
+		mockFunc := func(ctx Context) error {
+			return nil
+		}
+		rtr := NewRouter().(*router)
+
+		// Act
+		err := rtr.Any("/users", mockFunc)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, 9, len(rtr.GetAllowedMethods("/users")))
+	})
+
+	t.Run("return error of already used", func(t *testing.T) {
+		// Arrange
+		rtr := NewRouter().(*router)
+		rtr.GET("/users", nil)
+
+		// Act
+		err := rtr.Any("/users", nil)
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.NotNil(t, rtr.GetRoutesByMethod(http.MethodPost).Find("/users"))
+	})
+}
+
+func TestRouter_AddConflict(t *testing.T) {
+	// Arrange
+	rtr := NewRouter().(*router)
+	rtr.GET("/users/find", nil)
+	rtr.GET("/users/:id", nil)
+
+	// Act
+	errIntermediate := rtr.GET("/users", nil)
+	errDuplicate := rtr.GET("/users/:id", nil)
+	errAmbiguous := rtr.GET("/users/:name", nil)
+	errOtherMethod := rtr.POST("/users/:name", nil)
+
+	// Assert
+	assert.Nil(t, errIntermediate)
+	assert.ErrorIs(t, errDuplicate, perror.ErrRouteAlreadyUsed)
+	assert.ErrorIs(t, errAmbiguous, perror.ErrAmbiguousRoute)
+	assert.Equal(
+		t,
+		"[GET] /users/:name: ambiguous route: :name conflicts with :id at /users",
+		errAmbiguous.Error(),
+	)
+	assert.Nil(t, errOtherMethod)
+}
+
+func TestRouter_Remove(t *testing.T) {
+	// Arrange
+	rtr := NewRouter().(*router)
+	rtr.GET("/users/:id", nil, WithName("user"))
+
+	// Act
+	err := rtr.Remove(http.MethodGet, "/users/:id/")
+	errTwice := rtr.Remove(http.MethodGet, "/users/:id")
+	errMethod := rtr.Remove("PURGE", "/users/:id")
+	errReuseName := rtr.GET("/members/:id", nil, WithName("user"))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, errTwice, perror.ErrRouteNotFound)
+	assert.ErrorIs(t, errMethod, perror.ErrRouteNotFound)
+	assert.Nil(t, errReuseName)
+}
+
+func TestRouter_Clone(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	rtr := NewRouter().(*router)
+	rtr.GET("/users", mockFunc, WithName("users"))
+
+	// Act
+	cloned := rtr.clone()
+	cloned.POST("/users", mockFunc)
+	cloned.Remove(http.MethodGet, "/users")
+
+	// Assert
+	assert.Equal(t, []string{http.MethodGet}, rtr.GetAllowedMethods("/users"))
+	_, err := rtr.URL("users")
+	assert.Nil(t, err)
+	_, err = cloned.URL("users")
+	assert.ErrorIs(t, err, perror.ErrRouteNameNotFound)
+}
+
+func TestRouter_AddOptional(t *testing.T) {
+	// Arrange
+	mockFunc := func(ctx Context) error {
+		return nil
+	}
+	rtr := NewRouter().(*router)
+
+	// Act
+	err := rtr.GET("/reports/:year/:month?", mockFunc, WithName("report"))
+	errDuplicate := rtr.GET("/reports/:year", mockFunc)
+	errAmbiguous := rtr.GET("/reports/:y/:m?", mockFunc)
+	errInvalid := rtr.GET("/items/:id?/edit", mockFunc)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, errDuplicate, perror.ErrRouteAlreadyUsed)
+	assert.ErrorIs(t, errAmbiguous, perror.ErrAmbiguousRoute)
+	assert.ErrorIs(t, errInvalid, perror.ErrInvalidOptionalParam)
+
+	linear := rtr.DFS(http.MethodGet)
+	assert.Equal(t, 2, len(linear))
+	for _, lr := range linear {
+		assert.Equal(t, "/reports/:year/:month?", lr.pattern)
+		assert.Equal(t, "report", lr.name)
+	}
+
+	url, _ := rtr.URL("report", "year", "2025")
+	assert.Equal(t, "/reports/2025", url)
+
+	assert.Nil(t, rtr.Remove(http.MethodGet, "/reports/:year/:month?"))
+	assert.Equal(t, 0, len(rtr.DFS(http.MethodGet)))
+}
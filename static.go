@@ -0,0 +1,275 @@
+package poteto
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/poteto-go/poteto/perror"
+	"github.com/poteto-go/poteto/utils"
+)
+
+// catch-all of static route
+// EX: "/static" -> "/static/*filepath"
+const staticWildcard = "*filepath"
+
+type StaticConfig struct {
+	// file served for directory
+	Index string `yaml:"index"`
+
+	// file served if requested file is not found
+	// EX: "index.html" for SPA
+	// empty means 404
+	SPAFallback string `yaml:"spa_fallback"`
+
+	// list files of directory w/o index
+	Browse bool `yaml:"browse"`
+
+	// serve "<file>.gz" if exists & client accepts gzip
+	Precompressed bool `yaml:"precompressed"`
+}
+
+var DefaultStaticConfig = StaticConfig{
+	Index:         "index.html",
+	SPAFallback:   "",
+	Browse:        false,
+	Precompressed: true,
+}
+
+type staticServer struct {
+	prefix string
+	fsys   fs.FS
+	config StaticConfig
+	// name -> content ETag of file w/o modtime
+	// file w/o modtime is immutable ex: embed.FS
+	etags sync.Map
+}
+
+func newStaticHandler(prefix string, fsys fs.FS, config StaticConfig) HandlerFunc {
+	if config.Index == "" {
+		config.Index = DefaultStaticConfig.Index
+	}
+
+	server := &staticServer{
+		prefix: prefix,
+		fsys:   fsys,
+		config: config,
+	}
+	return server.serve
+}
+
+func (s *staticServer) serve(ctx Context) error {
+	name, _ := ctx.PathParam(staticWildcard[1:])
+
+	safePath, err := cleanStaticPath(name)
+	if err != nil {
+		httpErr := NewHttpError(http.StatusBadRequest)
+		httpErr.SetInternalError(err)
+		return httpErr
+	}
+
+	fsName := toFsName(safePath)
+	info, err := fs.Stat(s.fsys, fsName)
+	switch {
+	case err != nil:
+		return s.serveFallback(ctx)
+	case info.IsDir():
+		return s.serveDir(ctx, fsName, safePath)
+	default:
+		return s.serveFile(ctx, fsName)
+	}
+}
+
+func (s *staticServer) serveDir(ctx Context, fsName, safePath string) error {
+	index := path.Join(fsName, s.config.Index)
+	if info, err := fs.Stat(s.fsys, index); err == nil && !info.IsDir() {
+		return s.serveFile(ctx, index)
+	}
+
+	if !s.config.Browse {
+		return NewHttpError(http.StatusNotFound)
+	}
+	return s.listDir(ctx, fsName, safePath)
+}
+
+func (s *staticServer) serveFallback(ctx Context) error {
+	if s.config.SPAFallback == "" {
+		return NewHttpError(http.StatusNotFound)
+	}
+
+	return s.serveFile(ctx, toFsName(s.config.SPAFallback))
+}
+
+// ETag, Last-Modified, Range & HEAD are handled by http.ServeContent
+func (s *staticServer) serveFile(ctx Context, fsName string) error {
+	res := ctx.GetResponse()
+	req := ctx.GetRequest()
+
+	servedName := fsName
+	if s.config.Precompressed {
+		if info, err := fs.Stat(s.fsys, fsName+".gz"); err == nil && !info.IsDir() {
+			res.Header().Add(constant.HeaderVary, constant.HeaderAcceptEncoding)
+			if acceptsGzip(req.Header.Get(constant.HeaderAcceptEncoding)) {
+				servedName = fsName + ".gz"
+				res.Header().Set(constant.HeaderContentEncoding, "gzip")
+			}
+		}
+	}
+
+	file, err := s.fsys.Open(servedName)
+	if err != nil {
+		return NewHttpError(http.StatusNotFound)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	content, err := toReadSeeker(file)
+	if err != nil {
+		return err
+	}
+
+	etag, err := s.etag(servedName, info, content)
+	if err != nil {
+		return err
+	}
+	res.Header().Set(constant.HeaderETag, etag)
+
+	// content type is detected by original name, not by ".gz"
+	http.ServeContent(res, req, path.Base(fsName), info.ModTime(), content)
+	return nil
+}
+
+func (s *staticServer) listDir(ctx Context, fsName, safePath string) error {
+	entries, err := fs.ReadDir(s.fsys, fsName)
+	if err != nil {
+		return NewHttpError(http.StatusNotFound)
+	}
+
+	base := path.Join(s.prefix, safePath)
+	builder := strings.Builder{}
+	builder.WriteString("<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<pre>\n")
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		href := (&url.URL{Path: path.Join(base, entry.Name())}).String()
+		if entry.IsDir() {
+			href += "/"
+		}
+		builder.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>\n", html.EscapeString(href), html.EscapeString(name)))
+	}
+	builder.WriteString("</pre>\n")
+
+	ctx.SetResponseHeader(constant.HeaderContentType, "text/html; charset=utf-8")
+	ctx.WriteHeader(http.StatusOK)
+	_, err = ctx.GetResponse().Write([]byte(builder.String()))
+	return err
+}
+
+// "/css/main.css" -> "css/main.css"
+// "/" -> "."
+// ".." is rejected only as segment
+// "../secret" -> error
+// "a..b.txt" -> "/a..b.txt"
+func cleanStaticPath(name string) (string, error) {
+	if slices.Contains(strings.Split(name, "/"), "..") {
+		return "", perror.ErrPathTraversalNotAllowed
+	}
+
+	safePath := utils.CleanPath(name)
+	if len(safePath) > constant.MAX_DOMAIN_LENGTH {
+		return "", perror.ErrPathLengthExceeded
+	}
+	return safePath, nil
+}
+
+func toFsName(name string) string {
+	fsName := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if fsName == "" {
+		return "."
+	}
+	return fsName
+}
+
+// "gzip, deflate" -> true
+// "gzip;q=0" -> false
+func acceptsGzip(acceptEncoding string) bool {
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// embed.FS & os.DirFS file can seek
+// others are read into memory
+func toReadSeeker(file fs.File) (io.ReadSeeker, error) {
+	if readSeeker, ok := file.(io.ReadSeeker); ok {
+		return readSeeker, nil
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+// weak ETag from size & modtime
+// strong ETag from content if modtime is unknown (embed.FS)
+// content is hashed once per file
+func (s *staticServer) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if !info.ModTime().IsZero() {
+		return fmt.Sprintf("W/\"%x-%x\"", info.Size(), info.ModTime().UnixNano()), nil
+	}
+
+	if etag, ok := s.etags.Load(name); ok {
+		return etag.(string), nil
+	}
+
+	etag, err := contentETag(content)
+	if err != nil {
+		return "", err
+	}
+	s.etags.Store(name, etag)
+	return etag, nil
+}
+
+// content is rewound for http.ServeContent
+func contentETag(content io.ReadSeeker) (string, error) {
+	hash := fnv.New64a()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\"%x\"", hash.Sum64()), nil
+}
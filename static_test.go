@@ -0,0 +1,253 @@
+package poteto
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/poteto-go/poteto/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipForTest(s string) []byte {
+	buf := bytes.Buffer{}
+	writer := gzip.NewWriter(&buf)
+	writer.Write([]byte(s))
+	writer.Close()
+	return buf.Bytes()
+}
+
+func staticFSForTest() fstest.MapFS {
+	modTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return fstest.MapFS{
+		"index.html":       {Data: []byte("<h1>index</h1>"), ModTime: modTime},
+		"css/main.css":     {Data: []byte("body{}"), ModTime: modTime},
+		"js/app.js":        {Data: []byte("console.log(1)"), ModTime: modTime},
+		"js/app.js.gz":     {Data: gzipForTest("console.log(1)"), ModTime: modTime},
+		"docs/readme.txt":  {Data: []byte("0123456789")},
+		"docs/a b/doc.txt": {Data: []byte("doc")},
+		"docs/a..b.txt":    {Data: []byte("dots")},
+	}
+}
+
+func serveForTest(p Poteto, path string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	p.ServeHTTP(w, req)
+	return w
+}
+
+func TestPoteto_FileSystem(t *testing.T) {
+	// Arrange
+	p := New()
+	p.FileSystem("/static", staticFSForTest())
+
+	tests := []struct {
+		name                string
+		path                string
+		headers             map[string]string
+		expectedCode        int
+		expectedBody        string
+		expectedContentType string
+	}{
+		{"file", "/static/css/main.css", nil, http.StatusOK, "body{}", "text/css; charset=utf-8"},
+		{"index of root", "/static", nil, http.StatusOK, "<h1>index</h1>", "text/html; charset=utf-8"},
+		{"index w/ trailing slash", "/static/", nil, http.StatusOK, "<h1>index</h1>", "text/html; charset=utf-8"},
+		{"not found", "/static/none.css", nil, http.StatusNotFound, "", ""},
+		{"dir w/o index", "/static/docs", nil, http.StatusNotFound, "", ""},
+		{"path traversal", "/static/../secret", nil, http.StatusBadRequest, "", ""},
+		{"dots in file name", "/static/docs/a..b.txt", nil, http.StatusOK, "dots", ""},
+		{"range", "/static/docs/readme.txt", map[string]string{"Range": "bytes=2-4"}, http.StatusPartialContent, "234", ""},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			res := serveForTest(p, it.path, it.headers)
+
+			// Assert
+			assert.Equal(t, it.expectedCode, res.Code)
+			if it.expectedBody != "" {
+				assert.Equal(t, it.expectedBody, res.Body.String())
+			}
+			if it.expectedContentType != "" {
+				assert.Equal(t, it.expectedContentType, res.Header().Get(constant.HeaderContentType))
+			}
+		})
+	}
+}
+
+func TestPoteto_FileSystemCache(t *testing.T) {
+	// Arrange
+	p := New()
+	p.FileSystem("/static", staticFSForTest())
+
+	t.Run("etag & last-modified", func(t *testing.T) {
+		// Act
+		res := serveForTest(p, "/static/css/main.css", nil)
+		notModified := serveForTest(p, "/static/css/main.css", map[string]string{
+			"If-None-Match": res.Header().Get(constant.HeaderETag),
+		})
+		notModifiedSince := serveForTest(p, "/static/css/main.css", map[string]string{
+			"If-Modified-Since": res.Header().Get("Last-Modified"),
+		})
+
+		// Assert
+		assert.NotEqual(t, "", res.Header().Get(constant.HeaderETag))
+		assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", res.Header().Get("Last-Modified"))
+		assert.Equal(t, http.StatusNotModified, notModified.Code)
+		assert.Equal(t, http.StatusNotModified, notModifiedSince.Code)
+	})
+
+	t.Run("content etag w/o modtime", func(t *testing.T) {
+		// Act
+		res := serveForTest(p, "/static/docs/readme.txt", nil)
+
+		// Assert
+		assert.Regexp(t, `^"[0-9a-f]+"$`, res.Header().Get(constant.HeaderETag))
+		assert.Equal(t, "", res.Header().Get("Last-Modified"))
+	})
+
+	t.Run("content etag is computed once", func(t *testing.T) {
+		// Arrange
+		server := &staticServer{fsys: staticFSForTest()}
+		file, _ := server.fsys.Open("docs/readme.txt")
+		defer file.Close()
+		info, _ := file.Stat()
+		content, _ := toReadSeeker(file)
+
+		// Act
+		first, err := server.etag("docs/readme.txt", info, content)
+		// cached etag does not read content
+		second, errCached := server.etag("docs/readme.txt", info, nil)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, errCached)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("cached content etag serves whole body", func(t *testing.T) {
+		// Act
+		first := serveForTest(p, "/static/docs/readme.txt", nil)
+		second := serveForTest(p, "/static/docs/readme.txt", nil)
+
+		// Assert
+		assert.Equal(t, first.Header().Get(constant.HeaderETag), second.Header().Get(constant.HeaderETag))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.NotEqual(t, "", second.Body.String())
+	})
+}
+
+func TestPoteto_FileSystemPrecompressed(t *testing.T) {
+	// Arrange
+	p := New()
+	p.FileSystem("/static", staticFSForTest())
+
+	t.Run("serve gz if accepted", func(t *testing.T) {
+		// Act
+		res := serveForTest(p, "/static/js/app.js", map[string]string{
+			constant.HeaderAcceptEncoding: "gzip, deflate",
+		})
+
+		// Assert
+		assert.Equal(t, "gzip", res.Header().Get(constant.HeaderContentEncoding))
+		assert.Equal(t, constant.HeaderAcceptEncoding, res.Header().Get(constant.HeaderVary))
+		assert.Contains(t, res.Header().Get(constant.HeaderContentType), "javascript")
+		assert.Equal(t, gzipForTest("console.log(1)"), res.Body.Bytes())
+	})
+
+	t.Run("serve original if not accepted", func(t *testing.T) {
+		// Act
+		res := serveForTest(p, "/static/js/app.js", map[string]string{
+			constant.HeaderAcceptEncoding: "gzip;q=0, deflate",
+		})
+
+		// Assert
+		assert.Equal(t, "", res.Header().Get(constant.HeaderContentEncoding))
+		assert.Equal(t, "console.log(1)", res.Body.String())
+	})
+}
+
+func TestPoteto_FileSystemWithConfig(t *testing.T) {
+	t.Run("spa fallback", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.FileSystemWithConfig("/", staticFSForTest(), StaticConfig{SPAFallback: "index.html"})
+
+		// Act
+		res := serveForTest(p, "/users/1", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "<h1>index</h1>", res.Body.String())
+	})
+
+	t.Run("directory listing", func(t *testing.T) {
+		// Arrange
+		p := New()
+		p.FileSystemWithConfig("/static", staticFSForTest(), StaticConfig{Browse: true})
+
+		// Act
+		res := serveForTest(p, "/static/docs", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `<a href="/static/docs/a%20b/">a b/</a>`)
+		assert.Contains(t, res.Body.String(), `<a href="/static/docs/readme.txt">readme.txt</a>`)
+	})
+}
+
+func TestPoteto_Static(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello"), 0o644)
+	p := New()
+	err := p.Static("/files", dir)
+
+	// Act
+	res := serveForTest(p, "/files/hello.txt", nil)
+	head := httptest.NewRecorder()
+	p.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/files/hello.txt", nil))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", res.Body.String())
+	assert.Equal(t, http.StatusOK, head.Code)
+	assert.Equal(t, "5", head.Header().Get(constant.HeaderContentLength))
+	assert.Equal(t, "", head.Body.String())
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		expected       bool
+	}{
+		{"gzip", "gzip, deflate, br", true},
+		{"wildcard", "*", true},
+		{"refused", "gzip;q=0", false},
+		{"refused w/ decimal", "gzip; q=0.000", false},
+		{"weighted", "gzip;q=0.5", true},
+		{"none", "", false},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(t *testing.T) {
+			// Act
+			result := acceptsGzip(it.acceptEncoding)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}
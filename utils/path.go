@@ -42,3 +42,17 @@ func BuildSafeUrl(basePath, addPath string) (string, error) {
 
 	return fullPath, nil
 }
+
+// canonical request path
+//
+//	"/users/" -> "/users"
+//	"//users" -> "/users"
+//	"/users/./1/../2" -> "/users/2"
+//	"" -> "/"
+func CleanPath(p string) string {
+	if p == "" || p[0] != '/' {
+		p = "/" + p
+	}
+
+	return path.Clean(p)
+}
@@ -58,3 +58,29 @@ func TestBuildSafeUrl(t *testing.T) {
 		}
 	})
 }
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"/users", "/users"},
+		{"/users/", "/users"},
+		{"//users//1", "/users/1"},
+		{"/users/./1/../2", "/users/2"},
+		{"/../users", "/users"},
+		{"users", "/users"},
+		{"/", "/"},
+		{"", "/"},
+	}
+
+	for _, it := range tests {
+		t.Run(it.arg, func(t *testing.T) {
+			// Act
+			result := CleanPath(it.arg)
+
+			// Assert
+			assert.Equal(t, it.expected, result)
+		})
+	}
+}